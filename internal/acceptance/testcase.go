@@ -7,9 +7,9 @@ import (
 
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -98,16 +98,16 @@ func (td TestData) ResourceTest(t *testing.T, testResource TestResource, steps [
 
 func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
-	testCase.ProviderFactories = td.providers()
+	testCase.ProtoV5ProviderFactories = td.providers()
 
 	resource.ParallelTest(t, testCase)
 }
 
-func (td TestData) providers() map[string]func() (*schema.Provider, error) {
-	return map[string]func() (*schema.Provider, error){
-		"azapi": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.AzureProvider()
-			return azurerm, nil
+func (td TestData) providers() map[string]func() (tfprotov5.ProviderServer, error) {
+	return map[string]func() (tfprotov5.ProviderServer, error){
+		"azapi": func() (tfprotov5.ProviderServer, error) { //nolint:unparam
+			azapi := provider.AzureProviderServer()
+			return azapi, nil
 		},
 	}
 }
@@ -25,8 +25,8 @@ func BuildTestClient() (*clients.Client, error) {
 	if _client == nil {
 		var armEndpoint arm.Endpoint
 		var authEndpoint azidentity.AuthorityHost
-		env := os.Getenv("ARM_ENVIRONMENT")
-		switch strings.ToLower(env) {
+		env := strings.ToLower(os.Getenv("ARM_ENVIRONMENT"))
+		switch env {
 		case "public":
 			armEndpoint = arm.AzurePublicCloud
 			authEndpoint = azidentity.AzurePublicCloud
@@ -37,6 +37,7 @@ func BuildTestClient() (*clients.Client, error) {
 			armEndpoint = arm.AzureChina
 			authEndpoint = azidentity.AzureChina
 		default:
+			env = "public"
 			armEndpoint = arm.AzurePublicCloud
 			authEndpoint = azidentity.AzurePublicCloud
 		}
@@ -54,6 +55,7 @@ func BuildTestClient() (*clients.Client, error) {
 			SubscriptionId:           os.Getenv("ARM_SUBSCRIPTION_ID"),
 			Cred:                     cred,
 			ARMEndpoint:              armEndpoint,
+			Environment:              env,
 			Features:                 features.Default(),
 			SkipProviderRegistration: true,
 		}
@@ -0,0 +1,84 @@
+package azure
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+// DefaultTypesCacheSize is the max number of the parsed type files retained in memory.
+// A type file contains all the resource types of an api-version of a resource provider, so the definitions in the same file share the cache entry.
+const DefaultTypesCacheSize = 64
+
+var typesFileCache = newTypesCache(DefaultTypesCacheSize)
+
+// typesCache is a concurrency-safe LRU cache of the parsed type files, each file is parsed at most once while it's retained,
+// and the concurrent requests for the same file wait for the same parsing.
+type typesCache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	// order contains the entries, the most recently used one is in the front
+	order *list.List
+}
+
+type typesCacheEntry struct {
+	key    string
+	once   sync.Once
+	schema *types.Schema
+	err    error
+}
+
+func newTypesCache(capacity int) *typesCache {
+	return &typesCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the cached type file, it calls the load function if the file isn't cached. The failed loads are not cached.
+func (c *typesCache) get(key string, load func() (*types.Schema, error)) (*types.Schema, error) {
+	c.mutex.Lock()
+	var entry *typesCacheEntry
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		entry = element.Value.(*typesCacheEntry)
+	} else {
+		entry = &typesCacheEntry{key: key}
+		c.entries[key] = c.order.PushFront(entry)
+		c.evict()
+	}
+	c.mutex.Unlock()
+
+	entry.once.Do(func() {
+		entry.schema, entry.err = load()
+	})
+
+	if entry.err != nil {
+		c.mutex.Lock()
+		if element, ok := c.entries[key]; ok && element.Value == entry {
+			c.order.Remove(element)
+			delete(c.entries, key)
+		}
+		c.mutex.Unlock()
+	}
+	return entry.schema, entry.err
+}
+
+// len returns the number of the retained type files.
+func (c *typesCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+// evict removes the least recently used entries which exceed the capacity, it must be called with the lock held.
+func (c *typesCache) evict() {
+	for c.order.Len() > c.capacity && c.order.Len() > 0 {
+		element := c.order.Back()
+		c.order.Remove(element)
+		delete(c.entries, element.Value.(*typesCacheEntry).key)
+	}
+}
@@ -0,0 +1,103 @@
+package azure
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func Test_TypesCacheEviction(t *testing.T) {
+	cache := newTypesCache(2)
+	loads := make(map[string]int)
+	load := func(key string) func() (*types.Schema, error) {
+		return func() (*types.Schema, error) {
+			loads[key]++
+			return &types.Schema{}, nil
+		}
+	}
+
+	testData := []struct {
+		Key   string
+		Loads int
+	}{
+		{Key: "a", Loads: 1},
+		{Key: "b", Loads: 1},
+		{Key: "a", Loads: 1},
+		// b is the least recently used one and it's evicted
+		{Key: "c", Loads: 1},
+		{Key: "a", Loads: 1},
+		{Key: "b", Loads: 2},
+		// c is evicted
+		{Key: "c", Loads: 2},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Key)
+		if _, err := cache.get(v.Key, load(v.Key)); err != nil {
+			t.Fatal(err)
+		}
+		if loads[v.Key] != v.Loads {
+			t.Fatalf("Expected %d loads but got %d", v.Loads, loads[v.Key])
+		}
+		if cache.len() > 2 {
+			t.Fatalf("Expected at most %d entries but got %d", 2, cache.len())
+		}
+	}
+}
+
+func Test_TypesCacheError(t *testing.T) {
+	cache := newTypesCache(2)
+	loads := 0
+	load := func() (*types.Schema, error) {
+		loads++
+		if loads == 1 {
+			return nil, fmt.Errorf("failed to load")
+		}
+		return &types.Schema{}, nil
+	}
+	if _, err := cache.get("a", load); err == nil {
+		t.Fatalf("Expected an error but got nil")
+	}
+	if cache.len() != 0 {
+		t.Fatalf("Expected the failed load not to be cached")
+	}
+	if _, err := cache.get("a", load); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 {
+		t.Fatalf("Expected %d loads but got %d", 2, loads)
+	}
+}
+
+func Test_TypesCacheConcurrency(t *testing.T) {
+	cache := newTypesCache(4)
+	var loads int32
+	var wg sync.WaitGroup
+	results := make([]*types.Schema, 32)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			schema, err := cache.get("a", func() (*types.Schema, error) {
+				atomic.AddInt32(&loads, 1)
+				return &types.Schema{}, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = schema
+		}(i)
+	}
+	wg.Wait()
+	if loads != 1 {
+		t.Fatalf("Expected the file to be loaded once but got %d", loads)
+	}
+	for _, result := range results {
+		if result != results[0] {
+			t.Fatalf("Expected the same parsed file for the concurrent requests")
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// Package compiled implements a compact binary form of the bicep type files.
+//
+// The archive starts with a magic line and the length of the header, followed by the gob-encoded header and the type files.
+// The header contains the index of the resource types and the resource functions, and the ranges of the type files.
+// Each type file is a flate-compressed gob stream of flat type records, the type references are resolved to the record indexes,
+// and the identical types in the same file are deduplicated. The type files are decoded on demand.
+package compiled
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+const magic = "AZAPI-SCHEMA-1\n"
+
+// Location is the location of a type in the archive.
+type Location struct {
+	Path  string
+	Index int
+}
+
+// Index is the index of the resource types and the resource functions.
+type Index struct {
+	// Resources maps the resource types in a format like `<resource-type>@<api-version>` to the locations
+	Resources map[string]Location
+	// Functions maps the resource types to the api-versions and the locations of the resource functions
+	Functions map[string]map[string][]Location
+}
+
+// header is the gob-encoded header, the maps are stored as sorted slices so the archive is reproducible.
+type header struct {
+	Resources []resourceEntry
+	Functions []functionEntry
+	Files     []fileEntry
+}
+
+type resourceEntry struct {
+	Key      string
+	Location Location
+}
+
+type functionEntry struct {
+	ResourceType string
+	ApiVersion   string
+	Locations    []Location
+}
+
+type fileEntry struct {
+	Path   string
+	Offset int
+	Length int
+}
+
+type fileRange struct {
+	Offset int
+	Length int
+}
+
+// typeRecord is the flat form of a type, the references are the indexes of the records plus one, zero means no reference.
+type typeRecord struct {
+	Kind          types.TypeBaseKind
+	Name          string
+	Value         string
+	BuiltInKind   types.BuiltInTypeKind
+	Properties    []propertyRecord
+	Additional    int
+	ItemType      int
+	Elements      []int
+	Keys          []string
+	Discriminator string
+	Body          int
+	ScopeType     int
+	// WritableScopeType is only used if the mask has maskWritableScope
+	WritableScopeType int
+	// Mask marks the constraints which are specified, because gob doesn't send zero values
+	Mask         int
+	MinLength    int
+	MaxLength    int
+	MinValue     int64
+	MaxValue     int64
+	Pattern      string
+	Sensitive    bool
+	ResourceType string
+	ApiVersion   string
+	Input        int
+	Output       int
+}
+
+type propertyRecord struct {
+	Name           string
+	Type           int
+	Flags          int
+	Description    string
+	HasDescription bool
+}
+
+const (
+	maskMinLength = 1 << iota
+	maskMaxLength
+	maskMinValue
+	maskMaxValue
+	maskWritableScope
+)
+
+type file struct {
+	Records []typeRecord
+}
+
+// Archive is a decoded archive, the type files are decoded by Types.
+type Archive struct {
+	Index Index
+	files map[string]fileRange
+	data  []byte
+}
+
+// Open decodes the header of the archive, the data must not be modified after it's opened.
+func Open(data []byte) (*Archive, error) {
+	if !bytes.HasPrefix(data, []byte(magic)) {
+		return nil, fmt.Errorf("invalid schema archive, the magic line is missing")
+	}
+	data = data[len(magic):]
+	if len(data) < 4 {
+		return nil, fmt.Errorf("invalid schema archive, the header is missing")
+	}
+	length := int(binary.BigEndian.Uint32(data))
+	data = data[4:]
+	if len(data) < length {
+		return nil, fmt.Errorf("invalid schema archive, the header is truncated")
+	}
+	var h header
+	if err := gob.NewDecoder(bytes.NewReader(data[0:length])).Decode(&h); err != nil {
+		return nil, fmt.Errorf("invalid schema archive header: %+v", err)
+	}
+	archive := &Archive{
+		Index: Index{
+			Resources: make(map[string]Location, len(h.Resources)),
+			Functions: make(map[string]map[string][]Location),
+		},
+		files: make(map[string]fileRange, len(h.Files)),
+		data:  data[length:],
+	}
+	for _, entry := range h.Resources {
+		archive.Index.Resources[entry.Key] = entry.Location
+	}
+	for _, entry := range h.Functions {
+		if archive.Index.Functions[entry.ResourceType] == nil {
+			archive.Index.Functions[entry.ResourceType] = make(map[string][]Location)
+		}
+		archive.Index.Functions[entry.ResourceType][entry.ApiVersion] = entry.Locations
+	}
+	for _, entry := range h.Files {
+		archive.files[entry.Path] = fileRange{Offset: entry.Offset, Length: entry.Length}
+	}
+	return archive, nil
+}
+
+// Types decodes the type file in the path.
+func (a *Archive) Types(path string) (*types.Schema, error) {
+	r, ok := a.files[path]
+	if !ok {
+		return nil, fmt.Errorf("type file %s can't be found in the schema archive", path)
+	}
+	if r.Offset < 0 || r.Length < 0 || r.Offset+r.Length > len(a.data) {
+		return nil, fmt.Errorf("type file %s is out of the schema archive", path)
+	}
+	reader := flate.NewReader(bytes.NewReader(a.data[r.Offset : r.Offset+r.Length]))
+	defer reader.Close()
+	var f file
+	if err := gob.NewDecoder(reader).Decode(&f); err != nil {
+		return nil, fmt.Errorf("decoding type file %s: %+v", path, err)
+	}
+	return decodeRecords(f.Records)
+}
+
+func decodeRecords(records []typeRecord) (*types.Schema, error) {
+	// the pointers are allocated first, so the references to the types which are decoded later could be resolved
+	typeBases := make([]*types.TypeBase, len(records))
+	for i, record := range records {
+		// the types of unknown kinds are nil, the same as the json loader
+		if record.Kind != "" {
+			typeBases[i] = new(types.TypeBase)
+		}
+	}
+
+	reference := func(index int) (*types.TypeReference, error) {
+		if index == 0 {
+			return nil, nil
+		}
+		if index < 1 || index > len(typeBases) {
+			return nil, fmt.Errorf("type reference %d is out of range", index-1)
+		}
+		return &types.TypeReference{TypeIndex: index - 1, Type: typeBases[index-1]}, nil
+	}
+	properties := func(records []propertyRecord) (map[string]types.ObjectProperty, error) {
+		if records == nil {
+			return nil, nil
+		}
+		res := make(map[string]types.ObjectProperty, len(records))
+		for _, record := range records {
+			ref, err := reference(record.Type)
+			if err != nil {
+				return nil, err
+			}
+			property := types.ObjectProperty{
+				Type:  ref,
+				Flags: decodeFlags(record.Flags),
+			}
+			if record.HasDescription {
+				description := record.Description
+				property.Description = &description
+			}
+			res[record.Name] = property
+		}
+		return res, nil
+	}
+
+	var err error
+	for i, record := range records {
+		if typeBases[i] == nil {
+			continue
+		}
+		var t types.TypeBase
+		switch record.Kind {
+		case types.TypeBaseKindBuiltInType:
+			t = &types.BuiltInType{Kind: record.BuiltInKind}
+		case types.TypeBaseKindObjectType:
+			value := &types.ObjectType{Name: record.Name, Sensitive: record.Sensitive}
+			if value.Properties, err = properties(record.Properties); err != nil {
+				return nil, err
+			}
+			if value.AdditionalProperties, err = reference(record.Additional); err != nil {
+				return nil, err
+			}
+			t = value
+		case types.TypeBaseKindArrayType:
+			value := &types.ArrayType{}
+			if value.ItemType, err = reference(record.ItemType); err != nil {
+				return nil, err
+			}
+			value.MinLength, value.MaxLength = decodeLength(record)
+			t = value
+		case types.TypeBaseKindResourceType:
+			value := &types.ResourceType{Name: record.Name, ScopeTypes: decodeScopeTypes(record.ScopeType)}
+			if record.Mask&maskWritableScope != 0 {
+				value.WritableScopeTypes = types.ScopeTypesOf(record.WritableScopeType)
+			}
+			if value.Body, err = reference(record.Body); err != nil {
+				return nil, err
+			}
+			t = value
+		case types.TypeBaseKindUnionType:
+			value := &types.UnionType{}
+			if record.Elements != nil {
+				value.Elements = make([]*types.TypeReference, 0, len(record.Elements))
+				for _, element := range record.Elements {
+					ref, err := reference(element)
+					if err != nil {
+						return nil, err
+					}
+					value.Elements = append(value.Elements, ref)
+				}
+			}
+			t = value
+		case types.TypeBaseKindStringLiteralType:
+			t = &types.StringLiteralType{Value: record.Value}
+		case types.TypeBaseKindDiscriminatedObjectType:
+			value := &types.DiscriminatedObjectType{Name: record.Name, Discriminator: record.Discriminator}
+			if value.BaseProperties, err = properties(record.Properties); err != nil {
+				return nil, err
+			}
+			if len(record.Keys) != len(record.Elements) {
+				return nil, fmt.Errorf("the keys and the elements of %s don't match", record.Name)
+			}
+			if record.Keys != nil {
+				value.Elements = make(map[string]*types.TypeReference, len(record.Keys))
+				for index, key := range record.Keys {
+					if value.Elements[key], err = reference(record.Elements[index]); err != nil {
+						return nil, err
+					}
+				}
+			}
+			t = value
+		case types.TypeBaseKindResourceFunctionType:
+			// the resource function types are stored as values, the same as the json loader
+			value := types.ResourceFunctionType{Name: record.Name, ResourceType: record.ResourceType, ApiVersion: record.ApiVersion}
+			if value.Input, err = reference(record.Input); err != nil {
+				return nil, err
+			}
+			if value.Output, err = reference(record.Output); err != nil {
+				return nil, err
+			}
+			t = value
+		case types.TypeBaseKindIntegerType:
+			value := &types.IntegerType{}
+			if record.Mask&maskMinValue != 0 {
+				minValue := record.MinValue
+				value.MinValue = &minValue
+			}
+			if record.Mask&maskMaxValue != 0 {
+				maxValue := record.MaxValue
+				value.MaxValue = &maxValue
+			}
+			t = value
+		case types.TypeBaseKindStringType:
+			value := &types.StringType{Pattern: record.Pattern, Sensitive: record.Sensitive}
+			value.MinLength, value.MaxLength = decodeLength(record)
+			t = value
+		default:
+			return nil, fmt.Errorf("unknown type kind %s", record.Kind)
+		}
+		*typeBases[i] = t
+	}
+	return &types.Schema{Types: typeBases}, nil
+}
+
+func decodeLength(record typeRecord) (*int, *int) {
+	var minLength, maxLength *int
+	if record.Mask&maskMinLength != 0 {
+		value := record.MinLength
+		minLength = &value
+	}
+	if record.Mask&maskMaxLength != 0 {
+		value := record.MaxLength
+		maxLength = &value
+	}
+	return minLength, maxLength
+}
+
+// decodeFlags converts the flags the same as the json loader, the flags are nil if none is set.
+func decodeFlags(flags int) []types.ObjectPropertyFlag {
+	var res []types.ObjectPropertyFlag
+	for _, f := range types.PossibleObjectPropertyFlagValues() {
+		if flags&int(f) != 0 {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// decodeScopeTypes converts the scope types the same as the json loader, the unknown scope type is used if none is set.
+func decodeScopeTypes(scopeType int) []types.ScopeType {
+	scopeTypes := types.ScopeTypesOf(scopeType)
+	if scopeType == 0 {
+		scopeTypes = append(scopeTypes, types.Unknown)
+	}
+	return scopeTypes
+}
@@ -0,0 +1,147 @@
+package compiled_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/compiled"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+const testTypes = `[
+  {"$type": "StringType", "minLength": 3, "maxLength": 5},
+  {"$type": "StringType", "minLength": 3, "maxLength": 5},
+  {"$type": "IntegerType", "minValue": 0},
+  {"$type": "ObjectType", "name": "Properties", "properties": {"a": {"type": {"$ref": "#/0"}, "flags": 0}, "b": {"type": {"$ref": "#/2"}, "flags": 1}, "self": {"type": {"$ref": "#/3"}, "flags": 0}}},
+  {"$type": "ObjectType", "name": "Properties", "properties": {"a": {"type": {"$ref": "#/1"}, "flags": 0}, "b": {"type": {"$ref": "#/2"}, "flags": 1}, "self": {"type": {"$ref": "#/3"}, "flags": 0}}},
+  {"$type": "UnknownType"},
+  {"$type": "ObjectType", "name": "Body", "properties": {"first": {"type": {"$ref": "#/3"}, "flags": 0, "description": ""}, "second": {"type": {"$ref": "#/4"}, "flags": 0}, "unknown": {"type": {"$ref": "#/5"}, "flags": 0}}},
+  {"$type": "ResourceType", "name": "Microsoft.Test/foos@2024-01-01", "readableScopes": 12, "writableScopes": 8, "body": {"$ref": "#/6"}}
+]`
+
+func Test_Archive(t *testing.T) {
+	var schema types.Schema
+	if err := json.Unmarshal([]byte(testTypes), &schema); err != nil {
+		t.Fatal(err)
+	}
+	writer := compiled.NewWriter()
+	if err := writer.AddFile("test/types.json", &schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.AddResource("Microsoft.Test/foos@2024-01-01", compiled.Location{Path: "test/types.json", Index: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.AddResource("Microsoft.Test/bars@2024-01-01", compiled.Location{Path: "missing/types.json", Index: 0}); err == nil {
+		t.Fatalf("Expected an error when the type file is not added")
+	}
+	var buffer bytes.Buffer
+	if _, err := writer.WriteTo(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := compiled.Open(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	location, ok := archive.Index.Resources["Microsoft.Test/foos@2024-01-01"]
+	if !ok {
+		t.Fatalf("Expected the resource definition in the index")
+	}
+	decoded, err := archive.Types(location.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the identical string types and object types are deduplicated
+	if len(decoded.Types) != 6 {
+		t.Fatalf("Expected %d types but got %d", 6, len(decoded.Types))
+	}
+	resourceType, ok := (*decoded.Types[location.Index]).(*types.ResourceType)
+	if !ok {
+		t.Fatalf("Expected a resource type at index %d", location.Index)
+	}
+	if !reflect.DeepEqual(resourceType.ScopeTypes, []types.ScopeType{types.Subscription, types.ResourceGroup}) {
+		t.Fatalf("Expected the readable scopes %v but got %v", []types.ScopeType{types.Subscription, types.ResourceGroup}, resourceType.ScopeTypes)
+	}
+	if !reflect.DeepEqual(resourceType.WritableScopeTypes, []types.ScopeType{types.ResourceGroup}) {
+		t.Fatalf("Expected the writable scopes %v but got %v", []types.ScopeType{types.ResourceGroup}, resourceType.WritableScopeTypes)
+	}
+	body := (*resourceType.Body.Type).(*types.ObjectType)
+	if body.Properties["first"].Type.Type != body.Properties["second"].Type.Type {
+		t.Fatalf("Expected the identical object types to be deduplicated")
+	}
+	if description := body.Properties["first"].Description; description == nil || *description != "" {
+		t.Fatalf("Expected the empty description to be kept")
+	}
+	if body.Properties["unknown"].Type == nil || body.Properties["unknown"].Type.Type != nil {
+		t.Fatalf("Expected the reference to the unknown type to be kept without a type")
+	}
+
+	testData := []struct {
+		Body   interface{}
+		Errors int
+	}{
+		{
+			Body:   map[string]interface{}{"first": map[string]interface{}{"a": "abcd", "b": 1.0}},
+			Errors: 0,
+		},
+		{
+			Body:   map[string]interface{}{"first": map[string]interface{}{"a": "ab"}},
+			Errors: 2,
+		},
+		{
+			Body:   map[string]interface{}{"second": map[string]interface{}{"b": -1.0, "self": map[string]interface{}{"a": "abcdef", "b": 1.0}}},
+			Errors: 2,
+		},
+	}
+	for index, input := range testData {
+		t.Logf("[DEBUG] Testing Case %d", index)
+		errors := resourceType.Validate(input.Body, "")
+		if len(errors) != input.Errors {
+			t.Fatalf("Expected %d errors but got %v", input.Errors, errors)
+		}
+	}
+}
+
+func Test_ArchiveReproducible(t *testing.T) {
+	var outputs [][]byte
+	for i := 0; i < 2; i++ {
+		var schema types.Schema
+		if err := json.Unmarshal([]byte(testTypes), &schema); err != nil {
+			t.Fatal(err)
+		}
+		writer := compiled.NewWriter()
+		if err := writer.AddFile("test/types.json", &schema); err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"Microsoft.Test/foos@2024-01-01", "Microsoft.Test/foos@2024-02-01", "Microsoft.Test/bars@2024-01-01"} {
+			if err := writer.AddResource(key, compiled.Location{Path: "test/types.json", Index: 7}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var buffer bytes.Buffer
+		if _, err := writer.WriteTo(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, buffer.Bytes())
+	}
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		t.Fatalf("Expected the same archive for the same input")
+	}
+}
+
+func Test_OpenInvalidArchive(t *testing.T) {
+	testData := [][]byte{
+		nil,
+		[]byte("invalid"),
+		[]byte("AZAPI-SCHEMA-1\n"),
+		[]byte("AZAPI-SCHEMA-1\n\x00\x00\x01\x00"),
+	}
+	for index, input := range testData {
+		t.Logf("[DEBUG] Testing Case %d", index)
+		if _, err := compiled.Open(input); err == nil {
+			t.Fatalf("Expected an error but got nil")
+		}
+	}
+}
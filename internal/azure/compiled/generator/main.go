@@ -0,0 +1,103 @@
+// The generator compiles the bicep type files to the schema archive which is embedded in the provider.
+//
+// Usage:
+//
+//	go run ./compiled/generator -input generated -output compiled/schema.bin
+//
+// The generator depends on the azure package which embeds the archive, so the archive must exist before it's run.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/compiled"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func main() {
+	input := flag.String("input", "generated", "the folder which contains the index.json and the type files")
+	output := flag.String("output", "compiled/schema.bin", "the path of the schema archive")
+	flag.Parse()
+
+	if err := run(*input, *output); err != nil {
+		log.Fatalf("failed to compile the schemas: %+v", err)
+	}
+}
+
+func run(input, output string) error {
+	data, err := os.ReadFile(filepath.Join(input, "index.json"))
+	if err != nil {
+		return err
+	}
+	var index azure.Schema
+	if err := json.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("unmarshalling index.json: %+v", err)
+	}
+
+	locations := make([]azure.TypeLocation, 0)
+	for _, resource := range index.Resources {
+		for _, definition := range resource.Definitions {
+			locations = append(locations, definition.Location)
+		}
+	}
+	for _, versions := range index.Functions {
+		for _, values := range versions {
+			locations = append(locations, values...)
+		}
+	}
+
+	writer := compiled.NewWriter()
+	added := make(map[string]bool)
+	for _, location := range locations {
+		if added[location.Location] {
+			continue
+		}
+		added[location.Location] = true
+		data, err := os.ReadFile(filepath.Join(input, filepath.FromSlash(location.Location)))
+		if err != nil {
+			return err
+		}
+		var schema types.Schema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return fmt.Errorf("unmarshalling %s: %+v", location.Location, err)
+		}
+		if err := writer.AddFile(location.Location, &schema); err != nil {
+			return err
+		}
+	}
+
+	for resourceType, resource := range index.Resources {
+		for _, definition := range resource.Definitions {
+			key := fmt.Sprintf("%s@%s", resourceType, definition.ApiVersion)
+			if err := writer.AddResource(key, compiled.Location{Path: definition.Location.Location, Index: definition.Location.Index}); err != nil {
+				return err
+			}
+		}
+	}
+	for resourceType, versions := range index.Functions {
+		for apiVersion, values := range versions {
+			for _, location := range values {
+				if err := writer.AddFunction(resourceType, apiVersion, compiled.Location{Path: location.Location, Index: location.Index}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	if _, err := writer.WriteTo(&buffer); err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, buffer.Bytes(), 0o644); err != nil {
+		return err
+	}
+	log.Printf("[INFO] compiled %d type files to %s, %d bytes", len(added), output, buffer.Len())
+	return nil
+}
@@ -0,0 +1,373 @@
+package compiled
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+// Writer builds an archive, the type files must be added before the locations in them.
+type Writer struct {
+	files     map[string][]byte
+	indexes   map[string][]int
+	resources map[string]Location
+	functions map[string]map[string][]Location
+}
+
+func NewWriter() *Writer {
+	return &Writer{
+		files:     make(map[string][]byte),
+		indexes:   make(map[string][]int),
+		resources: make(map[string]Location),
+		functions: make(map[string]map[string][]Location),
+	}
+}
+
+// AddFile encodes the types in the type file, the identical types are deduplicated.
+func (w *Writer) AddFile(path string, schema *types.Schema) error {
+	records, err := encodeRecords(schema)
+	if err != nil {
+		return fmt.Errorf("encoding type file %s: %+v", path, err)
+	}
+	records, indexes := deduplicate(records)
+
+	var buffer bytes.Buffer
+	writer, err := flate.NewWriter(&buffer, flate.BestCompression)
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(writer).Encode(file{Records: records}); err != nil {
+		return fmt.Errorf("encoding type file %s: %+v", path, err)
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	w.files[path] = buffer.Bytes()
+	w.indexes[path] = indexes
+	return nil
+}
+
+// AddResource adds a resource definition, the key is in a format like `<resource-type>@<api-version>` and the location is the one in the type file.
+func (w *Writer) AddResource(key string, location Location) error {
+	location, err := w.location(location)
+	if err != nil {
+		return fmt.Errorf("resource definition %s: %+v", key, err)
+	}
+	w.resources[key] = location
+	return nil
+}
+
+// AddFunction adds a resource function, the location is the one in the type file.
+func (w *Writer) AddFunction(resourceType, apiVersion string, location Location) error {
+	location, err := w.location(location)
+	if err != nil {
+		return fmt.Errorf("resource function of %s@%s: %+v", resourceType, apiVersion, err)
+	}
+	if w.functions[resourceType] == nil {
+		w.functions[resourceType] = make(map[string][]Location)
+	}
+	w.functions[resourceType][apiVersion] = append(w.functions[resourceType][apiVersion], location)
+	return nil
+}
+
+// location maps the index in the type file to the index of the deduplicated record.
+func (w *Writer) location(location Location) (Location, error) {
+	indexes, ok := w.indexes[location.Path]
+	if !ok {
+		return location, fmt.Errorf("type file %s is not added", location.Path)
+	}
+	if location.Index < 0 || location.Index >= len(indexes) {
+		return location, fmt.Errorf("type index %d is out of range in %s", location.Index, location.Path)
+	}
+	return Location{Path: location.Path, Index: indexes[location.Index]}, nil
+}
+
+// WriteTo writes the archive, the output is the same for the same input.
+func (w *Writer) WriteTo(out io.Writer) (int64, error) {
+	h := header{
+		Resources: make([]resourceEntry, 0, len(w.resources)),
+		Functions: make([]functionEntry, 0),
+		Files:     make([]fileEntry, 0, len(w.files)),
+	}
+	for _, key := range sortedKeys(w.resources) {
+		h.Resources = append(h.Resources, resourceEntry{Key: key, Location: w.resources[key]})
+	}
+	for _, resourceType := range sortedKeys(w.functions) {
+		for _, apiVersion := range sortedKeys(w.functions[resourceType]) {
+			h.Functions = append(h.Functions, functionEntry{
+				ResourceType: resourceType,
+				ApiVersion:   apiVersion,
+				Locations:    w.functions[resourceType][apiVersion],
+			})
+		}
+	}
+	offset := 0
+	for _, path := range sortedKeys(w.files) {
+		h.Files = append(h.Files, fileEntry{Path: path, Offset: offset, Length: len(w.files[path])})
+		offset += len(w.files[path])
+	}
+
+	var headerBuffer bytes.Buffer
+	if err := gob.NewEncoder(&headerBuffer).Encode(h); err != nil {
+		return 0, err
+	}
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(headerBuffer.Len()))
+
+	written := int64(0)
+	chunks := [][]byte{[]byte(magic), length[:], headerBuffer.Bytes()}
+	for _, path := range sortedKeys(w.files) {
+		chunks = append(chunks, w.files[path])
+	}
+	for _, chunk := range chunks {
+		n, err := out.Write(chunk)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func encodeRecords(schema *types.Schema) ([]typeRecord, error) {
+	count := len(schema.Types)
+	reference := func(ref *types.TypeReference) (int, error) {
+		if ref == nil {
+			return 0, nil
+		}
+		if ref.TypeIndex < 0 || ref.TypeIndex >= count {
+			return 0, fmt.Errorf("type reference %d is out of range", ref.TypeIndex)
+		}
+		return ref.TypeIndex + 1, nil
+	}
+	properties := func(input map[string]types.ObjectProperty) ([]propertyRecord, error) {
+		if input == nil {
+			return nil, nil
+		}
+		res := make([]propertyRecord, 0, len(input))
+		for _, name := range sortedKeys(input) {
+			property := input[name]
+			ref, err := reference(property.Type)
+			if err != nil {
+				return nil, err
+			}
+			record := propertyRecord{
+				Name: name,
+				Type: ref,
+			}
+			for _, flag := range property.Flags {
+				record.Flags |= int(flag)
+			}
+			if property.Description != nil {
+				record.Description = *property.Description
+				record.HasDescription = true
+			}
+			res = append(res, record)
+		}
+		return res, nil
+	}
+	length := func(record *typeRecord, minLength, maxLength *int) {
+		if minLength != nil {
+			record.Mask |= maskMinLength
+			record.MinLength = *minLength
+		}
+		if maxLength != nil {
+			record.Mask |= maskMaxLength
+			record.MaxLength = *maxLength
+		}
+	}
+
+	records := make([]typeRecord, count)
+	var err error
+	for i, typeBase := range schema.Types {
+		if typeBase == nil || *typeBase == nil {
+			continue
+		}
+		record := &records[i]
+		switch t := (*typeBase).(type) {
+		case *types.BuiltInType:
+			record.Kind = types.TypeBaseKindBuiltInType
+			record.BuiltInKind = t.Kind
+		case *types.ObjectType:
+			record.Kind = types.TypeBaseKindObjectType
+			record.Name = t.Name
+			record.Sensitive = t.Sensitive
+			if record.Properties, err = properties(t.Properties); err != nil {
+				return nil, err
+			}
+			if record.Additional, err = reference(t.AdditionalProperties); err != nil {
+				return nil, err
+			}
+		case *types.ArrayType:
+			record.Kind = types.TypeBaseKindArrayType
+			if record.ItemType, err = reference(t.ItemType); err != nil {
+				return nil, err
+			}
+			length(record, t.MinLength, t.MaxLength)
+		case *types.ResourceType:
+			record.Kind = types.TypeBaseKindResourceType
+			record.Name = t.Name
+			for _, scopeType := range t.ScopeTypes {
+				record.ScopeType |= int(scopeType)
+			}
+			if t.WritableScopeTypes != nil {
+				record.Mask |= maskWritableScope
+				for _, scopeType := range t.WritableScopeTypes {
+					record.WritableScopeType |= int(scopeType)
+				}
+			}
+			if record.Body, err = reference(t.Body); err != nil {
+				return nil, err
+			}
+		case *types.UnionType:
+			record.Kind = types.TypeBaseKindUnionType
+			if t.Elements != nil {
+				record.Elements = make([]int, 0, len(t.Elements))
+				for _, element := range t.Elements {
+					ref, err := reference(element)
+					if err != nil {
+						return nil, err
+					}
+					record.Elements = append(record.Elements, ref)
+				}
+			}
+		case *types.StringLiteralType:
+			record.Kind = types.TypeBaseKindStringLiteralType
+			record.Value = t.Value
+		case *types.DiscriminatedObjectType:
+			record.Kind = types.TypeBaseKindDiscriminatedObjectType
+			record.Name = t.Name
+			record.Discriminator = t.Discriminator
+			if record.Properties, err = properties(t.BaseProperties); err != nil {
+				return nil, err
+			}
+			if t.Elements != nil {
+				record.Keys = sortedKeys(t.Elements)
+				record.Elements = make([]int, 0, len(t.Elements))
+				for _, key := range record.Keys {
+					ref, err := reference(t.Elements[key])
+					if err != nil {
+						return nil, err
+					}
+					record.Elements = append(record.Elements, ref)
+				}
+			}
+		case types.ResourceFunctionType:
+			record.Kind = types.TypeBaseKindResourceFunctionType
+			record.Name = t.Name
+			record.ResourceType = t.ResourceType
+			record.ApiVersion = t.ApiVersion
+			if record.Input, err = reference(t.Input); err != nil {
+				return nil, err
+			}
+			if record.Output, err = reference(t.Output); err != nil {
+				return nil, err
+			}
+		case *types.IntegerType:
+			record.Kind = types.TypeBaseKindIntegerType
+			if t.MinValue != nil {
+				record.Mask |= maskMinValue
+				record.MinValue = *t.MinValue
+			}
+			if t.MaxValue != nil {
+				record.Mask |= maskMaxValue
+				record.MaxValue = *t.MaxValue
+			}
+		case *types.StringType:
+			record.Kind = types.TypeBaseKindStringType
+			record.Pattern = t.Pattern
+			record.Sensitive = t.Sensitive
+			length(record, t.MinLength, t.MaxLength)
+		default:
+			return nil, fmt.Errorf("unsupported type %T", t)
+		}
+	}
+	return records, nil
+}
+
+// deduplicate merges the identical records, the records are identical if they have the same fields and reference the identical records.
+// It returns the deduplicated records and the new indexes of the input records.
+func deduplicate(records []typeRecord) ([]typeRecord, []int) {
+	// canonical is the index of the first record which is identical to the record
+	canonical := make([]int, len(records))
+	for i := range canonical {
+		canonical[i] = i
+	}
+	for changed := true; changed; {
+		changed = false
+		seen := make(map[string]int)
+		for i := range records {
+			key := fmt.Sprintf("%#v", remapRecord(records[i], canonical))
+			if first, ok := seen[key]; ok {
+				if canonical[i] != canonical[first] {
+					canonical[i] = canonical[first]
+					changed = true
+				}
+			} else {
+				seen[key] = i
+			}
+		}
+	}
+
+	indexes := make([]int, len(records))
+	output := make([]typeRecord, 0)
+	for i := range records {
+		if canonical[i] == i {
+			indexes[i] = len(output)
+			output = append(output, records[i])
+		}
+	}
+	for i := range records {
+		indexes[i] = indexes[canonical[i]]
+	}
+	for i := range output {
+		output[i] = remapRecord(output[i], indexes)
+	}
+	return output, indexes
+}
+
+// remapRecord returns a copy of the record whose references are mapped by the indexes.
+func remapRecord(record typeRecord, indexes []int) typeRecord {
+	ref := func(index int) int {
+		if index == 0 {
+			return 0
+		}
+		return indexes[index-1] + 1
+	}
+	record.Additional = ref(record.Additional)
+	record.ItemType = ref(record.ItemType)
+	record.Body = ref(record.Body)
+	record.Input = ref(record.Input)
+	record.Output = ref(record.Output)
+	if record.Elements != nil {
+		elements := make([]int, len(record.Elements))
+		for i, element := range record.Elements {
+			elements[i] = ref(element)
+		}
+		record.Elements = elements
+	}
+	if record.Properties != nil {
+		properties := make([]propertyRecord, len(record.Properties))
+		for i, property := range record.Properties {
+			property.Type = ref(property.Type)
+			properties[i] = property
+		}
+		record.Properties = properties
+	}
+	return record
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,295 @@
+package azure
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+// Test_CompiledSchemaParity checks that the compiled schemas are the same as the bicep type files they're compiled from,
+// run `go generate ./internal/azure/` if it fails after the type files are updated.
+func Test_CompiledSchemaParity(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the parity check of all the resource definitions in short mode")
+	}
+	data, err := os.ReadFile("generated/index.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jsonIndex Schema
+	if err := json.Unmarshal(data, &jsonIndex); err != nil {
+		t.Fatal(err)
+	}
+	compiledIndex := GetAzureSchema()
+	if compiledIndex == nil {
+		t.Fatal("failed to load the compiled schema index")
+	}
+	fsys := os.DirFS("generated")
+
+	// the definitions are grouped by the type files, so each file is loaded once
+	files := make(map[string][]string)
+	locations := make(map[string]TypeLocation)
+	count := 0
+	for resourceType, resource := range jsonIndex.Resources {
+		for _, definition := range resource.Definitions {
+			id := resourceType + "@" + definition.ApiVersion
+			location := definition.Location
+			location.fsys = fsys
+			locations[id] = location
+			files[location.Location] = append(files[location.Location], id)
+			count++
+		}
+	}
+	definitions := make(map[string]ResourceDefinition)
+	for resourceType, resource := range compiledIndex.Resources {
+		for _, definition := range resource.Definitions {
+			definitions[resourceType+"@"+definition.ApiVersion] = definition
+		}
+	}
+	if len(definitions) != count {
+		t.Fatalf("Expected %d resource definitions but got %d", count, len(definitions))
+	}
+
+	for path, ids := range files {
+		jsonLocation := locations[ids[0]]
+		jsonTypes, err := jsonLocation.loadTypes()
+		if err != nil {
+			t.Fatalf("loading %s: %+v", path, err)
+		}
+		compiledTypes, err := archive.Types(path)
+		if err != nil {
+			t.Fatalf("loading compiled %s: %+v", path, err)
+		}
+		for _, id := range ids {
+			jsonLocation := locations[id]
+			expected, err := jsonLocation.resourceType(jsonTypes)
+			if err != nil {
+				t.Fatalf("%s: %+v", id, err)
+			}
+			definition, ok := definitions[id]
+			if !ok {
+				t.Fatalf("%s can't be found in the compiled schema index", id)
+			}
+			if definition.Location.Location != path {
+				t.Fatalf("%s: Expected location %q but got %q", id, path, definition.Location.Location)
+			}
+			actual, err := definition.Location.resourceType(compiledTypes)
+			if err != nil {
+				t.Fatalf("%s: %+v", id, err)
+			}
+			if !equalTypes(expected.AsTypeBase(), actual.AsTypeBase(), make(map[[2]types.TypeBase]bool)) {
+				t.Fatalf("%s: the compiled definition is different from the json definition", id)
+			}
+			for _, invalid := range []bool{false, true} {
+				body := sampleBody(*expected.Body.Type, invalid, 0)
+				expectedErrors := errorMessages(expected.Validate(body, ""))
+				actualErrors := errorMessages(actual.Validate(body, ""))
+				if !reflect.DeepEqual(expectedErrors, actualErrors) {
+					t.Fatalf("%s: Expected validation errors %q but got %q", id, expectedErrors, actualErrors)
+				}
+				if !reflect.DeepEqual(expected.GetWriteOnly(body), actual.GetWriteOnly(body)) {
+					t.Fatalf("%s: the write-only bodies are different", id)
+				}
+			}
+		}
+	}
+}
+
+func errorMessages(errors []error) []string {
+	res := make([]string, 0, len(errors))
+	for _, err := range errors {
+		res = append(res, err.Error())
+	}
+	sort.Strings(res)
+	return res
+}
+
+// sampleBody builds a body which contains all the properties, the values have mismatched types if invalid is true.
+func sampleBody(t types.TypeBase, invalid bool, depth int) interface{} {
+	if depth > 3 {
+		return nil
+	}
+	switch v := t.(type) {
+	case *types.ObjectType:
+		body := sampleProperties(v.Properties, invalid, depth)
+		if v.AdditionalProperties != nil && v.AdditionalProperties.Type != nil {
+			body["additional"] = sampleBody(*v.AdditionalProperties.Type, invalid, depth+1)
+		}
+		return body
+	case *types.DiscriminatedObjectType:
+		body := sampleProperties(v.BaseProperties, invalid, depth)
+		keys := make([]string, 0, len(v.Elements))
+		for key := range v.Elements {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if len(keys) != 0 {
+			if element := v.Elements[keys[0]]; element != nil && element.Type != nil {
+				if object, ok := (*element.Type).(*types.ObjectType); ok {
+					for key, value := range sampleProperties(object.Properties, invalid, depth) {
+						body[key] = value
+					}
+				}
+			}
+			body[v.Discriminator] = keys[0]
+		}
+		return body
+	case *types.ArrayType:
+		if v.ItemType == nil || v.ItemType.Type == nil {
+			return []interface{}{}
+		}
+		return []interface{}{sampleBody(*v.ItemType.Type, invalid, depth+1)}
+	case *types.UnionType:
+		if len(v.Elements) == 0 || v.Elements[0] == nil || v.Elements[0].Type == nil {
+			return nil
+		}
+		return sampleBody(*v.Elements[0].Type, invalid, depth+1)
+	case *types.StringLiteralType:
+		if invalid {
+			return 1.0
+		}
+		return v.Value
+	case *types.StringType:
+		if invalid {
+			return 1.0
+		}
+		return "value"
+	case *types.IntegerType:
+		if invalid {
+			return "value"
+		}
+		return 1.0
+	case *types.BuiltInType:
+		switch v.Kind {
+		case types.Bool:
+			if invalid {
+				return "value"
+			}
+			return true
+		case types.Int:
+			if invalid {
+				return "value"
+			}
+			return 1.0
+		case types.Object:
+			return map[string]interface{}{}
+		case types.Array:
+			return []interface{}{}
+		}
+		if invalid {
+			return 1.0
+		}
+		return "value"
+	}
+	return nil
+}
+
+func sampleProperties(properties map[string]types.ObjectProperty, invalid bool, depth int) map[string]interface{} {
+	body := make(map[string]interface{})
+	for name, property := range properties {
+		if property.Type != nil && property.Type.Type != nil {
+			body[name] = sampleBody(*property.Type.Type, invalid, depth+1)
+		}
+	}
+	return body
+}
+
+// equalTypes compares the types structurally, the type indexes are ignored because the compiled types are deduplicated.
+func equalTypes(a, b *types.TypeBase, visited map[[2]types.TypeBase]bool) bool {
+	if a == nil || b == nil || *a == nil || *b == nil {
+		return (a == nil || *a == nil) && (b == nil || *b == nil)
+	}
+	pair := [2]types.TypeBase{*a, *b}
+	if visited[pair] {
+		return true
+	}
+	visited[pair] = true
+
+	switch x := (*a).(type) {
+	case *types.BuiltInType:
+		y, ok := (*b).(*types.BuiltInType)
+		return ok && x.Kind == y.Kind
+	case *types.ObjectType:
+		y, ok := (*b).(*types.ObjectType)
+		return ok && x.Name == y.Name && x.Sensitive == y.Sensitive &&
+			equalProperties(x.Properties, y.Properties, visited) &&
+			equalReferences(x.AdditionalProperties, y.AdditionalProperties, visited)
+	case *types.ArrayType:
+		y, ok := (*b).(*types.ArrayType)
+		return ok && reflect.DeepEqual(x.MinLength, y.MinLength) && reflect.DeepEqual(x.MaxLength, y.MaxLength) &&
+			equalReferences(x.ItemType, y.ItemType, visited)
+	case *types.ResourceType:
+		y, ok := (*b).(*types.ResourceType)
+		return ok && x.Name == y.Name && reflect.DeepEqual(x.ScopeTypes, y.ScopeTypes) && reflect.DeepEqual(x.WritableScopeTypes, y.WritableScopeTypes) &&
+			equalReferences(x.Body, y.Body, visited)
+	case *types.UnionType:
+		y, ok := (*b).(*types.UnionType)
+		if !ok || len(x.Elements) != len(y.Elements) {
+			return false
+		}
+		for i := range x.Elements {
+			if !equalReferences(x.Elements[i], y.Elements[i], visited) {
+				return false
+			}
+		}
+		return true
+	case *types.StringLiteralType:
+		y, ok := (*b).(*types.StringLiteralType)
+		return ok && x.Value == y.Value
+	case *types.DiscriminatedObjectType:
+		y, ok := (*b).(*types.DiscriminatedObjectType)
+		if !ok || x.Name != y.Name || x.Discriminator != y.Discriminator || len(x.Elements) != len(y.Elements) {
+			return false
+		}
+		for key, element := range x.Elements {
+			if _, ok := y.Elements[key]; !ok || !equalReferences(element, y.Elements[key], visited) {
+				return false
+			}
+		}
+		return equalProperties(x.BaseProperties, y.BaseProperties, visited)
+	case types.ResourceFunctionType:
+		y, ok := (*b).(types.ResourceFunctionType)
+		return ok && x.Name == y.Name && x.ResourceType == y.ResourceType && x.ApiVersion == y.ApiVersion &&
+			equalReferences(x.Input, y.Input, visited) && equalReferences(x.Output, y.Output, visited)
+	case *types.IntegerType:
+		y, ok := (*b).(*types.IntegerType)
+		return ok && reflect.DeepEqual(x.MinValue, y.MinValue) && reflect.DeepEqual(x.MaxValue, y.MaxValue)
+	case *types.StringType:
+		y, ok := (*b).(*types.StringType)
+		return ok && x.Pattern == y.Pattern && x.Sensitive == y.Sensitive &&
+			reflect.DeepEqual(x.MinLength, y.MinLength) && reflect.DeepEqual(x.MaxLength, y.MaxLength)
+	}
+	return false
+}
+
+func equalReferences(a, b *types.TypeReference, visited map[[2]types.TypeBase]bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return equalTypes(a.Type, b.Type, visited)
+}
+
+func equalProperties(a, b map[string]types.ObjectProperty, visited map[[2]types.TypeBase]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, x := range a {
+		y, ok := b[name]
+		if !ok || !reflect.DeepEqual(x.Description, y.Description) || len(x.Flags) != len(y.Flags) {
+			return false
+		}
+		for i := range x.Flags {
+			if x.Flags[i] != y.Flags[i] {
+				return false
+			}
+		}
+		if !equalReferences(x.Type, y.Type, visited) {
+			return false
+		}
+	}
+	return true
+}
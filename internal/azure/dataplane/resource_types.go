@@ -0,0 +1,167 @@
+package dataplane
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+// ResourceType describes how to manage a data-plane resource, whose endpoint is derived from its parent ARM resource.
+type ResourceType struct {
+	// ParentType is the ARM resource type of the parent resource, for example, `Microsoft.KeyVault/vaults`.
+	ParentType string
+
+	// UrlTemplate is the data-plane url of the resource. The placeholders are replaced with the segments of the parent id, like `{vaults}`,
+	// the `NameParts` and `{dnsSuffix}`.
+	UrlTemplate string
+
+	// NameParts are the placeholders which are built from the name, the name is split by `/`. The last part takes the rest of the name,
+	// so it may contain `/`, like the keys of the app configuration.
+	NameParts []string
+
+	// QuoteNameParts is true if the name parts are OData string literals in the url, their single quotes are doubled.
+	QuoteNameParts bool
+
+	// DnsSuffixes maps the cloud environment to the dns suffix of the data-plane endpoint.
+	DnsSuffixes map[string]string
+
+	// Scopes maps the cloud environment to the scope of the access token.
+	Scopes map[string]string
+
+	// ApiVersions are the supported api-versions.
+	ApiVersions []string
+
+	// ApiVersionHeader is the header used to specify the api-version, the `api-version` query parameter is used if it's empty.
+	ApiVersionHeader string
+
+	// Headers are the extra headers sent with every request.
+	Headers map[string]string
+
+	// DeleteHeaders are the extra headers sent with the delete request.
+	DeleteHeaders map[string]string
+}
+
+// resourceTypes are the supported data-plane resource types. The storage container ACL isn't supported, because its requests and responses
+// are XML documents (`SignedIdentifiers`) and the public access level is a header, while the data-plane resources share the JSON body and
+// output semantics of `azapi_resource`. The table entities are supported as the storage data-plane resource type instead.
+var resourceTypes = map[string]ResourceType{
+	"Microsoft.KeyVault/vaults/secrets": {
+		ParentType:  "Microsoft.KeyVault/vaults",
+		UrlTemplate: "https://{vaults}.{dnsSuffix}/secrets/{name}",
+		NameParts:   []string{"name"},
+		DnsSuffixes: map[string]string{
+			"public":       "vault.azure.net",
+			"usgovernment": "vault.usgovcloudapi.net",
+			"china":        "vault.azure.cn",
+		},
+		Scopes: map[string]string{
+			"public":       "https://vault.azure.net/.default",
+			"usgovernment": "https://vault.usgovcloudapi.net/.default",
+			"china":        "https://vault.azure.cn/.default",
+		},
+		ApiVersions: []string{"7.3", "7.4"},
+	},
+
+	"Microsoft.AppConfiguration/configurationStores/keyValues": {
+		ParentType:  "Microsoft.AppConfiguration/configurationStores",
+		UrlTemplate: "https://{configurationStores}.{dnsSuffix}/kv/{name}",
+		NameParts:   []string{"name"},
+		DnsSuffixes: map[string]string{
+			"public":       "azconfig.io",
+			"usgovernment": "azconfig.azure.us",
+			"china":        "azconfig.azure.cn",
+		},
+		Scopes: map[string]string{
+			"public":       "https://azconfig.io/.default",
+			"usgovernment": "https://azconfig.azure.us/.default",
+			"china":        "https://azconfig.azure.cn/.default",
+		},
+		ApiVersions: []string{"1.0"},
+		Headers: map[string]string{
+			"Content-Type": "application/vnd.microsoft.appconfig.kv+json",
+		},
+	},
+
+	"Microsoft.Storage/storageAccounts/tableServices/tables/entities": {
+		ParentType:     "Microsoft.Storage/storageAccounts/tableServices/tables",
+		UrlTemplate:    "https://{storageAccounts}.table.{dnsSuffix}/{tables}(PartitionKey='{partitionKey}',RowKey='{rowKey}')",
+		NameParts:      []string{"partitionKey", "rowKey"},
+		QuoteNameParts: true,
+		DnsSuffixes: map[string]string{
+			"public":       "core.windows.net",
+			"usgovernment": "core.usgovcloudapi.net",
+			"china":        "core.chinacloudapi.cn",
+		},
+		Scopes: map[string]string{
+			"public":       "https://storage.azure.com/.default",
+			"usgovernment": "https://storage.azure.com/.default",
+			"china":        "https://storage.azure.com/.default",
+		},
+		ApiVersions:      []string{"2019-02-02", "2020-12-06"},
+		ApiVersionHeader: "x-ms-version",
+		Headers: map[string]string{
+			"Accept":       "application/json;odata=nometadata",
+			"Content-Type": "application/json",
+		},
+		DeleteHeaders: map[string]string{
+			"If-Match": "*",
+		},
+	},
+}
+
+// GetResourceType returns the definition of the data-plane resource type, the resource type is case-insensitive.
+func GetResourceType(resourceType string) (string, *ResourceType) {
+	for key, value := range resourceTypes {
+		if strings.EqualFold(key, resourceType) {
+			value := value
+			return key, &value
+		}
+	}
+	return "", nil
+}
+
+// ResourceTypes returns the supported data-plane resource types in order.
+func ResourceTypes() []string {
+	res := make([]string, 0)
+	for key := range resourceTypes {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// BuildUrl builds the data-plane url of the resource from its parent id and name.
+func (t ResourceType) BuildUrl(parentId string, name string, environment string) (string, error) {
+	dnsSuffix, ok := t.DnsSuffixes[environment]
+	if !ok {
+		return "", fmt.Errorf("environment %q is not supported", environment)
+	}
+
+	names := strings.SplitN(name, "/", len(t.NameParts))
+	if len(names) != len(t.NameParts) {
+		return "", fmt.Errorf("expect name in format `%s`, but got %q", strings.Join(t.NameParts, "/"), name)
+	}
+
+	// the values are escaped, so the placeholders can't be introduced by the name or the parent id
+	dataPlaneUrl := strings.ReplaceAll(t.UrlTemplate, "{dnsSuffix}", dnsSuffix)
+	for index, part := range t.NameParts {
+		if names[index] == "" {
+			return "", fmt.Errorf("expect name in format `%s`, but got %q", strings.Join(t.NameParts, "/"), name)
+		}
+		value := names[index]
+		if t.QuoteNameParts {
+			value = strings.ReplaceAll(value, "'", "''")
+		}
+		dataPlaneUrl = strings.ReplaceAll(dataPlaneUrl, fmt.Sprintf("{%s}", part), url.PathEscape(value))
+	}
+	for key, value := range utils.GetIdParts(parentId) {
+		dataPlaneUrl = strings.ReplaceAll(dataPlaneUrl, fmt.Sprintf("{%s}", key), url.PathEscape(value))
+	}
+	if strings.Contains(dataPlaneUrl, "{") {
+		return "", fmt.Errorf("failed to build the url from parent id %q, got %q", parentId, dataPlaneUrl)
+	}
+	return dataPlaneUrl, nil
+}
@@ -0,0 +1,143 @@
+package dataplane_test
+
+import (
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/dataplane"
+)
+
+func Test_GetResourceType(t *testing.T) {
+	for _, resourceType := range dataplane.ResourceTypes() {
+		t.Logf("[DEBUG] Testing Value %s", resourceType)
+
+		key, def := dataplane.GetResourceType(resourceType)
+		if key != resourceType || def == nil {
+			t.Fatalf("Expected definition of %s but got none", resourceType)
+		}
+		if len(def.ApiVersions) == 0 || len(def.NameParts) == 0 {
+			t.Fatalf("Expected api-versions and name parts of %s", resourceType)
+		}
+		for _, environment := range []string{"public", "usgovernment", "china"} {
+			if def.DnsSuffixes[environment] == "" || def.Scopes[environment] == "" {
+				t.Fatalf("Expected dns suffix and scope of %s in %s", resourceType, environment)
+			}
+		}
+	}
+
+	if key, _ := dataplane.GetResourceType("microsoft.keyvault/VAULTS/secrets"); key != "Microsoft.KeyVault/vaults/secrets" {
+		t.Fatalf("Expected %s but got %s", "Microsoft.KeyVault/vaults/secrets", key)
+	}
+	if _, def := dataplane.GetResourceType("Microsoft.KeyVault/vaults/keys"); def != nil {
+		t.Fatalf("Expected no definition but got %v", def)
+	}
+}
+
+func Test_BuildUrl(t *testing.T) {
+	cases := []struct {
+		ResourceType string
+		ParentId     string
+		Name         string
+		Environment  string
+		Output       string
+		Error        bool
+	}{
+		{
+			ResourceType: "Microsoft.KeyVault/vaults/secrets",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			Name:         "secret1",
+			Environment:  "public",
+			Output:       "https://vault1.vault.azure.net/secrets/secret1",
+		},
+		{
+			ResourceType: "Microsoft.KeyVault/vaults/secrets",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			Name:         "secret1",
+			Environment:  "china",
+			Output:       "https://vault1.vault.azure.cn/secrets/secret1",
+		},
+		{
+			ResourceType: "Microsoft.KeyVault/vaults/secrets",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			Name:         "secret1",
+			Environment:  "unknown",
+			Error:        true,
+		},
+		{
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.AppConfiguration/configurationStores/store1",
+			Name:         "key1",
+			Environment:  "public",
+			Output:       "https://store1.azconfig.io/kv/key1",
+		},
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts/tableServices/tables/entities",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/account1/tableServices/default/tables/table1",
+			Name:         "partition1/row1",
+			Environment:  "public",
+			Output:       "https://account1.table.core.windows.net/table1(PartitionKey='partition1',RowKey='row1')",
+		},
+		{
+			// the keys of the app configuration may contain `/`
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.AppConfiguration/configurationStores/store1",
+			Name:         "app1/settings/color",
+			Environment:  "public",
+			Output:       "https://store1.azconfig.io/kv/app1%2Fsettings%2Fcolor",
+		},
+		{
+			ResourceType: "Microsoft.KeyVault/vaults/secrets",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			Name:         "secret 1?{name}",
+			Environment:  "public",
+			Output:       "https://vault1.vault.azure.net/secrets/secret%201%3F%7Bname%7D",
+		},
+		{
+			// the single quotes are doubled in the OData keys, the row key may contain `/`
+			ResourceType: "Microsoft.Storage/storageAccounts/tableServices/tables/entities",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/account1/tableServices/default/tables/table1",
+			Name:         "o'neil/row 1/a",
+			Environment:  "public",
+			Output:       "https://account1.table.core.windows.net/table1(PartitionKey='o%27%27neil',RowKey='row%201%2Fa')",
+		},
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts/tableServices/tables/entities",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/account1/tableServices/default/tables/table1",
+			Name:         "partition1/",
+			Environment:  "public",
+			Error:        true,
+		},
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts/tableServices/tables/entities",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/account1/tableServices/default/tables/table1",
+			Name:         "row1",
+			Environment:  "public",
+			Error:        true,
+		},
+		{
+			ResourceType: "Microsoft.KeyVault/vaults/secrets",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1",
+			Name:         "secret1",
+			Environment:  "public",
+			Error:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s %s %s", tc.ResourceType, tc.ParentId, tc.Name)
+
+		_, def := dataplane.GetResourceType(tc.ResourceType)
+		output, err := def.BuildUrl(tc.ParentId, tc.Name, tc.Environment)
+		if err != nil {
+			if tc.Error {
+				continue
+			}
+			t.Fatalf("Expected no error but got %+v", err)
+		}
+		if tc.Error {
+			t.Fatalf("Expected an error but got none")
+		}
+		if output != tc.Output {
+			t.Fatalf("Expected %s but got %s", tc.Output, output)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package azure
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SchemaPathEnvName is the environment variable which specifies the path of the external schemas.
+const SchemaPathEnvName = "ARM_SCHEMA_PATH"
+
+// ExternalSchema describes the external schemas which are overlaid on the embedded schemas.
+type ExternalSchema struct {
+	Path string
+	// Added is the number of the resource definitions which don't exist in the embedded schemas
+	Added int
+	// Overridden is the number of the embedded resource definitions which are replaced by the external schemas
+	Overridden int
+}
+
+var (
+	externalSchemaMutex sync.Mutex
+	externalSchemas     = make(map[string]*ExternalSchema)
+)
+
+// LoadExternalSchema overlays the bicep type files in a directory or a zip archive on the embedded schemas.
+// The `index.json` must be in the root or the `generated` folder of the directory or the archive.
+// The external resource definitions take precedence over the embedded ones with the same resource type and api-version,
+// and the schemas loaded later take precedence over the ones loaded earlier. Loading the same path more than once is a no-op.
+func LoadExternalSchema(path string) (*ExternalSchema, error) {
+	externalSchemaMutex.Lock()
+	defer externalSchemaMutex.Unlock()
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if loaded, ok := externalSchemas[absPath]; ok {
+		return loaded, nil
+	}
+
+	fsys, err := openSchemaFS(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the schemas in %s: %+v", path, err)
+	}
+	data, err := fs.ReadFile(fsys, "index.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the schema index in %s: %+v", path, err)
+	}
+	var index Schema
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the schema index in %s: %+v", path, err)
+	}
+
+	azureSchema := GetAzureSchema()
+	if azureSchema == nil {
+		return nil, fmt.Errorf("failed to load azure schema index")
+	}
+	schemaMutex.Lock()
+	external := azureSchema.overlay(&index, absPath, fsys)
+	schemaMutex.Unlock()
+	log.Printf("[INFO] loaded the schemas in %s, %d resource definitions are added and %d embedded resource definitions are overridden", absPath, external.Added, external.Overridden)
+	externalSchemas[absPath] = external
+	return external, nil
+}
+
+// GetResourceDefinitionSource returns the path of the external schemas which contain the resource definition, it returns an empty string for the embedded schemas.
+func GetResourceDefinitionSource(resourceType, apiVersion string) string {
+	if definition := findResourceDefinition(resourceType, apiVersion); definition != nil {
+		return definition.Location.Source
+	}
+	return ""
+}
+
+func (o *Schema) overlay(index *Schema, source string, fsys fs.FS) *ExternalSchema {
+	external := &ExternalSchema{
+		Path: source,
+	}
+	if o.Resources == nil {
+		o.Resources = make(map[string]*Resource)
+	}
+	// the resource types are case-insensitive, the existing resources are mapped by the lower-cased resource types
+	resources := make(map[string]*Resource, len(o.Resources))
+	for resourceType, resource := range o.Resources {
+		resources[strings.ToLower(resourceType)] = resource
+	}
+	for resourceType, resource := range index.Resources {
+		key := strings.ToLower(resourceType)
+		target := resources[key]
+		if target == nil {
+			target = &Resource{
+				Definitions: make([]ResourceDefinition, 0),
+			}
+			o.Resources[resourceType] = target
+			resources[key] = target
+		}
+		for _, definition := range resource.Definitions {
+			definition.Location.Source = source
+			definition.Location.fsys = fsys
+			overridden := false
+			for i := range target.Definitions {
+				if target.Definitions[i].ApiVersion == definition.ApiVersion {
+					target.Definitions[i] = definition
+					overridden = true
+					break
+				}
+			}
+			if overridden {
+				external.Overridden++
+			} else {
+				target.Definitions = append(target.Definitions, definition)
+				external.Added++
+			}
+		}
+	}
+
+	if o.Functions == nil {
+		o.Functions = make(map[string]map[string][]TypeLocation)
+	}
+	for resourceType, versions := range index.Functions {
+		if o.Functions[resourceType] == nil {
+			o.Functions[resourceType] = make(map[string][]TypeLocation)
+		}
+		for apiVersion, locations := range versions {
+			for i := range locations {
+				locations[i].Source = source
+				locations[i].fsys = fsys
+			}
+			o.Functions[resourceType][apiVersion] = locations
+		}
+	}
+	o.buildIndex()
+	return external
+}
+
+// openSchemaFS opens the directory or the zip archive, it returns the folder which contains the `index.json`.
+func openSchemaFS(path string) (fs.FS, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var fsys fs.FS
+	if info.IsDir() {
+		fsys = os.DirFS(path)
+	} else {
+		if !strings.EqualFold(filepath.Ext(path), ".zip") {
+			return nil, fmt.Errorf("%s is neither a directory nor a zip archive", path)
+		}
+		// the archive is kept open, because the type files are loaded on demand
+		reader, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		fsys = reader
+	}
+	if _, err := fs.Stat(fsys, "index.json"); err == nil {
+		return fsys, nil
+	}
+	if _, err := fs.Stat(fsys, "generated/index.json"); err == nil {
+		return fs.Sub(fsys, "generated")
+	}
+	return nil, fmt.Errorf("index.json can't be found in the root or the `generated` folder")
+}
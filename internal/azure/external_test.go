@@ -0,0 +1,175 @@
+package azure_test
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+)
+
+const externalIndex = `{
+  "resources": {
+    "Microsoft.Addons/supportProviders/supportPlanTypes@2018-03-01": {"$ref": "addons/types.json#/2"},
+    "Microsoft.%[1]s/tests@2099-01-01": {"$ref": "addons/types.json#/2"}
+  },
+  "resourceFunctions": {}
+}`
+
+const externalTypes = `[
+  {"$type": "StringType", "minLength": 3},
+  {"$type": "ObjectType", "name": "Test", "properties": {"name": {"type": {"$ref": "#/0"}, "flags": 9}, "properties": {"type": {"$ref": "#/3"}, "flags": 1}}},
+  {"$type": "ResourceType", "name": "Test", "readableScopes": 8, "writableScopes": 8, "body": {"$ref": "#/1"}},
+  {"$type": "ObjectType", "name": "Properties", "properties": {"value": {"type": {"$ref": "#/0"}, "flags": 1}}}
+]`
+
+func writeExternalSchema(t *testing.T, dir string, namespace string) {
+	files := map[string]string{
+		"generated/index.json":        strings.ReplaceAll(externalIndex, "%[1]s", namespace),
+		"generated/addons/types.json": externalTypes,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func Test_LoadExternalSchemaDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeExternalSchema(t, dir, "ExternalDir")
+
+	external, err := azure.LoadExternalSchema(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if external.Added != 1 || external.Overridden != 1 {
+		t.Fatalf("Expected 1 added and 1 overridden definitions but got %d and %d", external.Added, external.Overridden)
+	}
+
+	// the same path is loaded only once
+	if again, err := azure.LoadExternalSchema(dir); err != nil || again != external {
+		t.Fatalf("Expected the loaded external schema but got %v, %v", again, err)
+	}
+
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Source       string
+		Body         string
+		Errors       int
+	}{
+		{
+			ResourceType: "Microsoft.ExternalDir/tests",
+			ApiVersion:   "2099-01-01",
+			Source:       external.Path,
+			Body:         `{"properties": {"value": "abc"}}`,
+			Errors:       0,
+		},
+		{
+			ResourceType: "microsoft.externaldir/tests",
+			ApiVersion:   "2099-01-01",
+			Source:       external.Path,
+			Body:         `{"properties": {"value": "ab"}}`,
+			Errors:       1,
+		},
+		{
+			// the overridden definition
+			ResourceType: "Microsoft.Addons/supportProviders/supportPlanTypes",
+			ApiVersion:   "2018-03-01",
+			Source:       external.Path,
+			Body:         `{"properties": {}}`,
+			Errors:       1,
+		},
+		{
+			// the embedded definition of the other api-version is kept
+			ResourceType: "Microsoft.Addons/supportProviders/supportPlanTypes",
+			ApiVersion:   "2017-05-15",
+			Source:       "",
+			Body:         `{}`,
+			Errors:       0,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s", v.ResourceType, v.ApiVersion)
+		if source := azure.GetResourceDefinitionSource(v.ResourceType, v.ApiVersion); source != v.Source {
+			t.Fatalf("Expected source %q but got %q", v.Source, source)
+		}
+		var body interface{}
+		if err := json.Unmarshal([]byte(v.Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		if errors := azure.ValidateBody(v.ResourceType, v.ApiVersion, body); len(errors) != v.Errors {
+			t.Fatalf("Expected %d errors but got %v", v.Errors, errors)
+		}
+	}
+}
+
+func Test_LoadExternalSchemaArchive(t *testing.T) {
+	dir := t.TempDir()
+	writeExternalSchema(t, dir, "ExternalZip")
+
+	archivePath := filepath.Join(t.TempDir(), "schemas.zip")
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := zip.NewWriter(file)
+	for _, name := range []string{"generated/index.json", "generated/addons/types.json"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := azure.LoadExternalSchema(archivePath); err != nil {
+		t.Fatal(err)
+	}
+	if versions := azure.GetApiVersions("Microsoft.ExternalZip/tests"); len(versions) != 1 || versions[0] != "2099-01-01" {
+		t.Fatalf("Expected api-versions %v but got %v", []string{"2099-01-01"}, versions)
+	}
+	def, err := azure.GetResourceDefinition("Microsoft.ExternalZip/tests", "2099-01-01")
+	if err != nil || def == nil {
+		t.Fatalf("Expected the resource definition but got %v, %v", def, err)
+	}
+}
+
+func Test_LoadExternalSchemaInvalid(t *testing.T) {
+	testData := []string{
+		filepath.Join(t.TempDir(), "not-exist"),
+		t.TempDir(),
+	}
+	file := filepath.Join(t.TempDir(), "schemas.tar")
+	if err := os.WriteFile(file, []byte("schemas"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	testData = append(testData, file)
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v)
+		if _, err := azure.LoadExternalSchema(v); err == nil {
+			t.Fatalf("Expected an error but got nil")
+		}
+	}
+}
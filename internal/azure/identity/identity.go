@@ -1,7 +1,6 @@
 package identity
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
@@ -108,10 +107,8 @@ func ExpandIdentity(input []interface{}) (interface{}, error) {
 	config["type"] = identityType
 	identityIds := v["identity_ids"].([]interface{})
 	userAssignedIdentities := make(map[string]interface{}, len(identityIds))
+	// whether the `identity_ids` match the `type` is checked by the `identity_user_assigned` validation rule
 	if len(identityIds) != 0 {
-		if identityType != UserAssigned && identityType != SystemAssignedUserAssigned {
-			return nil, fmt.Errorf("`identity_ids` can only be specified when `type` includes `UserAssigned`")
-		}
 		for _, id := range identityIds {
 			userAssignedIdentities[id.(string)] = make(map[string]interface{})
 		}
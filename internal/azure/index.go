@@ -3,13 +3,19 @@ package azure
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"strings"
 
+	"github.com/Azure/terraform-provider-azapi/internal/azure/compiled"
 	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
 )
 
 type Schema struct {
 	Resources map[string]*Resource
+	// Functions maps the lower-cased resource types to the api-versions and the locations of the resource functions like `listKeys`
+	Functions map[string]map[string][]TypeLocation
+	// index is the case-insensitive lookup index of the resource definitions, it's rebuilt when the resources are changed
+	index *lookupIndex
 }
 
 type Resource struct {
@@ -25,41 +31,134 @@ type ResourceDefinition struct {
 type TypeLocation struct {
 	Location string `json:"RelativePath"`
 	Index    int    `json:"Index"`
+	// Source is the path of the external schemas which contain the type, it's empty for the embedded schemas
+	Source string `json:"-"`
+	// fsys is the file system of the external schemas, the embedded compiled schemas are used if it's nil
+	fsys fs.FS
 }
 
+// UnmarshalJSON supports the location like `{"RelativePath": "path/types.json", "Index": 5}` in the old bicep-types format,
+// and the reference like `{"$ref": "path/types.json#/5"}` in the current format.
+func (o *TypeLocation) UnmarshalJSON(body []byte) error {
+	var m struct {
+		Ref          *string `json:"$ref"`
+		RelativePath string  `json:"RelativePath"`
+		Index        int     `json:"Index"`
+	}
+	if err := json.Unmarshal(body, &m); err != nil {
+		return err
+	}
+	if m.Ref == nil {
+		o.Location = m.RelativePath
+		o.Index = m.Index
+		return nil
+	}
+	index := strings.LastIndex(*m.Ref, "#")
+	if index == -1 {
+		return fmt.Errorf("type reference %q is invalid, expect a reference like `<relative-path>#/<index>`", *m.Ref)
+	}
+	typeIndex, err := types.ParseTypeIndex((*m.Ref)[index:])
+	if err != nil {
+		return err
+	}
+	o.Location = (*m.Ref)[0:index]
+	o.Index = typeIndex
+	return nil
+}
+
+// UnmarshalJSON supports the index in the old bicep-types format which contains `Resources` and `Functions`,
+// and the index in the current format which contains `resources` and `resourceFunctions`.
 func (o *Schema) UnmarshalJSON(body []byte) error {
-	var m map[string]map[string]TypeLocation
+	var m map[string]*json.RawMessage
 	err := json.Unmarshal(body, &m)
 	if err != nil {
 		return err
 	}
-	if m["Resources"] == nil {
-		return nil
+
+	resources := m["Resources"]
+	if resources == nil {
+		resources = m["resources"]
+	}
+	if resources != nil {
+		var resourceLocations map[string]TypeLocation
+		if err := json.Unmarshal(*resources, &resourceLocations); err != nil {
+			return err
+		}
+		o.Resources = make(map[string]*Resource)
+		for k, v := range resourceLocations {
+			if err := o.addResource(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	functions := m["Functions"]
+	if functions == nil {
+		functions = m["resourceFunctions"]
+	}
+	if functions != nil {
+		var functionLocations map[string]map[string][]TypeLocation
+		if err := json.Unmarshal(*functions, &functionLocations); err != nil {
+			return err
+		}
+		o.Functions = make(map[string]map[string][]TypeLocation)
+		for k, v := range functionLocations {
+			o.Functions[strings.ToLower(k)] = v
+		}
 	}
-	o.Resources = make(map[string]*Resource)
-	for k, v := range m["Resources"] {
-		index := strings.Index(k, "@")
-		if index == -1 {
-			return fmt.Errorf("api-version is not specified, type: %s", k)
+
+	o.buildIndex()
+	return nil
+}
+
+// newSchema builds the schema index from the index of the compiled schemas.
+func newSchema(index compiled.Index) (*Schema, error) {
+	o := &Schema{
+		Resources: make(map[string]*Resource),
+		Functions: make(map[string]map[string][]TypeLocation),
+	}
+	for k, v := range index.Resources {
+		if err := o.addResource(k, TypeLocation{Location: v.Path, Index: v.Index}); err != nil {
+			return nil, err
 		}
-		resourceType := k[0:index]
-		resource := o.Resources[resourceType]
-		if resource == nil {
-			o.Resources[resourceType] = &Resource{
-				Definitions: make([]ResourceDefinition, 0),
+	}
+	for resourceType, versions := range index.Functions {
+		o.Functions[strings.ToLower(resourceType)] = make(map[string][]TypeLocation)
+		for apiVersion, values := range versions {
+			locations := make([]TypeLocation, 0, len(values))
+			for _, v := range values {
+				locations = append(locations, TypeLocation{Location: v.Path, Index: v.Index})
 			}
-			resource = o.Resources[resourceType]
+			o.Functions[strings.ToLower(resourceType)][apiVersion] = locations
 		}
-		resource.Definitions = append(resource.Definitions, ResourceDefinition{
-			Definition: nil,
-			Location:   v,
-			ApiVersion: k[index+1:],
-		})
 	}
+	o.buildIndex()
+	return o, nil
+}
 
+// addResource adds the resource definition, the key is in a format like `<resource-type>@<api-version>`.
+func (o *Schema) addResource(key string, location TypeLocation) error {
+	index := strings.Index(key, "@")
+	if index == -1 {
+		return fmt.Errorf("api-version is not specified, type: %s", key)
+	}
+	resourceType := key[0:index]
+	resource := o.Resources[resourceType]
+	if resource == nil {
+		o.Resources[resourceType] = &Resource{
+			Definitions: make([]ResourceDefinition, 0),
+		}
+		resource = o.Resources[resourceType]
+	}
+	resource.Definitions = append(resource.Definitions, ResourceDefinition{
+		Definition: nil,
+		Location:   location,
+		ApiVersion: key[index+1:],
+	})
 	return nil
 }
 
+// GetDefinition returns the resource definition, the type files are parsed once and shared by the definitions in the same file.
 func (o *ResourceDefinition) GetDefinition() (*types.ResourceType, error) {
 	if o == nil {
 		return nil, nil
@@ -67,19 +166,33 @@ func (o *ResourceDefinition) GetDefinition() (*types.ResourceType, error) {
 	if o.Definition != nil {
 		return o.Definition, nil
 	}
-	definition, err := o.Location.LoadDefinition()
+	schema, err := typesFileCache.get(o.Location.Source+"#"+o.Location.Location, o.Location.loadTypes)
 	if err != nil {
 		return nil, err
 	}
-	o.Definition = definition
-	return o.Definition, nil
+	return o.Location.resourceType(schema)
 }
 
+// LoadDefinition parses the type file and returns the resource definition, it doesn't use the cache.
 func (o *TypeLocation) LoadDefinition() (*types.ResourceType, error) {
 	if o == nil {
 		return nil, nil
 	}
-	data, err := StaticFiles.ReadFile("generated/" + o.Location)
+	schema, err := o.loadTypes()
+	if err != nil {
+		return nil, err
+	}
+	return o.resourceType(schema)
+}
+
+func (o *TypeLocation) loadTypes() (*types.Schema, error) {
+	if o.fsys == nil {
+		if archive == nil {
+			return nil, fmt.Errorf("failed to load azure schema index")
+		}
+		return archive.Types(o.Location)
+	}
+	data, err := fs.ReadFile(o.fsys, o.Location)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +201,10 @@ func (o *TypeLocation) LoadDefinition() (*types.ResourceType, error) {
 	if err != nil {
 		return nil, err
 	}
+	return &schema, nil
+}
+
+func (o *TypeLocation) resourceType(schema *types.Schema) (*types.ResourceType, error) {
 	if o.Index < len(schema.Types) && schema.Types[o.Index] != nil {
 		if resourceType, ok := (*schema.Types[o.Index]).(*types.ResourceType); ok {
 			return resourceType, nil
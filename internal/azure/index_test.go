@@ -0,0 +1,66 @@
+package azure_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+)
+
+func Test_SchemaIndexFormats(t *testing.T) {
+	testData := []struct {
+		Name  string
+		Index string
+	}{
+		{
+			Name: "old format",
+			Index: `{
+  "Resources": {
+    "Microsoft.Test/tests@2022-01-01": {"RelativePath": "test/microsoft.test/2022-01-01/types.json", "Index": 12}
+  },
+  "Functions": {
+    "Microsoft.Test/tests": {"2022-01-01": [{"RelativePath": "test/microsoft.test/2022-01-01/types.json", "Index": 20}]}
+  }
+}`,
+		},
+		{
+			Name: "current format",
+			Index: `{
+  "resources": {
+    "Microsoft.Test/tests@2022-01-01": {"$ref": "test/microsoft.test/2022-01-01/types.json#/12"}
+  },
+  "resourceFunctions": {
+    "Microsoft.Test/tests": {"2022-01-01": [{"$ref": "test/microsoft.test/2022-01-01/types.json#/20"}]}
+  },
+  "settings": {"name": "Test", "isSingleton": false}
+}`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Name)
+		var schema azure.Schema
+		if err := json.Unmarshal([]byte(v.Index), &schema); err != nil {
+			t.Fatal(err)
+		}
+		resource := schema.Resources["Microsoft.Test/tests"]
+		if resource == nil || len(resource.Definitions) != 1 {
+			t.Fatalf("Expected 1 definition but got %v", resource)
+		}
+		definition := resource.Definitions[0]
+		if definition.ApiVersion != "2022-01-01" || definition.Location.Location != "test/microsoft.test/2022-01-01/types.json" || definition.Location.Index != 12 {
+			t.Fatalf("Expected %q but got %v", "test/microsoft.test/2022-01-01/types.json#/12", definition)
+		}
+		functions := schema.Functions["microsoft.test/tests"]["2022-01-01"]
+		if len(functions) != 1 || functions[0].Location != "test/microsoft.test/2022-01-01/types.json" || functions[0].Index != 20 {
+			t.Fatalf("Expected %q but got %v", "test/microsoft.test/2022-01-01/types.json#/20", functions)
+		}
+	}
+}
+
+func Test_SchemaIndexInvalidReference(t *testing.T) {
+	var schema azure.Schema
+	if err := json.Unmarshal([]byte(`{"resources": {"Microsoft.Test/tests@2022-01-01": {"$ref": "types.json"}}}`), &schema); err == nil {
+		t.Fatalf("Expected an error but got nil")
+	}
+}
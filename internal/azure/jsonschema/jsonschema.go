@@ -0,0 +1,317 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var invalidNameRegex = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// Generate converts the type to a draft 2020-12 JSON Schema document, the title is used as the document's title.
+// The object types are defined in `$defs` and referenced by `$ref`, so the recursive types are supported.
+// The rules follow the provider's schema validation:
+//  1. The properties which are not defined in the object types are not allowed.
+//  2. The discriminated object types are converted to `oneOf`, each element requires the discriminator with a `const` value.
+//  3. The union types of string literals are converted to `enum`.
+//  4. The read-only and write-only properties are annotated with `readOnly` and `writeOnly`.
+//  5. The `name` of the resource body is not required, because it's specified by the resource's `name` field.
+func Generate(title string, t types.TypeBase) map[string]interface{} {
+	g := generator{
+		names: make(map[types.TypeBase]string),
+		used:  make(map[string]bool),
+		defs:  make(map[string]interface{}),
+	}
+	root := t
+	if resourceType, ok := t.(*types.ResourceType); ok {
+		root = referenceType(resourceType.Body)
+		g.root = root
+	}
+	document := g.convert(root)
+	document["$schema"] = Draft
+	if title != "" {
+		document["title"] = title
+	}
+	if len(g.defs) != 0 {
+		document["$defs"] = g.defs
+	}
+	return document
+}
+
+type generator struct {
+	// root is the body type of the resource type
+	root  types.TypeBase
+	names map[types.TypeBase]string
+	used  map[string]bool
+	defs  map[string]interface{}
+}
+
+func (g *generator) convert(t types.TypeBase) map[string]interface{} {
+	switch v := t.(type) {
+	case *types.ResourceType:
+		return g.convert(referenceType(v.Body))
+	case *types.BuiltInType:
+		return builtInSchema(v.Kind)
+	case *types.StringLiteralType:
+		return map[string]interface{}{
+			"type":  "string",
+			"const": v.Value,
+		}
+	case *types.StringType:
+		schema := map[string]interface{}{
+			"type": "string",
+		}
+		if v.MinLength != nil {
+			schema["minLength"] = *v.MinLength
+		}
+		if v.MaxLength != nil {
+			schema["maxLength"] = *v.MaxLength
+		}
+		if v.Pattern != "" {
+			schema["pattern"] = v.Pattern
+		}
+		return schema
+	case *types.IntegerType:
+		schema := map[string]interface{}{
+			"type": "integer",
+		}
+		if v.MinValue != nil {
+			schema["minimum"] = *v.MinValue
+		}
+		if v.MaxValue != nil {
+			schema["maximum"] = *v.MaxValue
+		}
+		return schema
+	case *types.ArrayType:
+		schema := map[string]interface{}{
+			"type":  "array",
+			"items": g.convert(referenceType(v.ItemType)),
+		}
+		if v.MinLength != nil {
+			schema["minItems"] = *v.MinLength
+		}
+		if v.MaxLength != nil {
+			schema["maxItems"] = *v.MaxLength
+		}
+		return schema
+	case *types.UnionType:
+		if values := stringLiteralValues(v); values != nil {
+			return map[string]interface{}{
+				"type": "string",
+				"enum": values,
+			}
+		}
+		anyOf := make([]interface{}, 0)
+		for _, element := range v.Elements {
+			anyOf = append(anyOf, g.convert(referenceType(element)))
+		}
+		return map[string]interface{}{
+			"anyOf": anyOf,
+		}
+	case *types.ObjectType, *types.DiscriminatedObjectType:
+		return map[string]interface{}{
+			"$ref": "#/$defs/" + g.define(v),
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// define adds the definition of the object type to `$defs` if it's not defined, and returns the name of the definition.
+func (g *generator) define(t types.TypeBase) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := g.uniqueName(typeName(t))
+	g.names[t] = name
+	// the name is registered before the conversion, so the recursive references could be resolved
+	switch v := t.(type) {
+	case *types.ObjectType:
+		g.defs[name] = g.objectSchema(v, t == g.root)
+	case *types.DiscriminatedObjectType:
+		g.defs[name] = g.discriminatedObjectSchema(v, t == g.root)
+	}
+	return name
+}
+
+func (g *generator) uniqueName(name string) string {
+	// the names are used in the json pointers of `$ref`, so the characters like `/` are replaced
+	name = invalidNameRegex.ReplaceAllString(name, "_")
+	if name == "" {
+		name = "object"
+	}
+	result := name
+	for index := 2; g.used[result]; index++ {
+		result = fmt.Sprintf("%s_%d", name, index)
+	}
+	g.used[result] = true
+	return result
+}
+
+func (g *generator) objectSchema(t *types.ObjectType, isRoot bool) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type": "object",
+	}
+	properties, required := g.propertiesSchema(t.Properties)
+	if isRoot {
+		required = remove(required, "name")
+	}
+	if len(properties) != 0 {
+		schema["properties"] = properties
+	}
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+	if t.AdditionalProperties != nil && t.AdditionalProperties.Type != nil {
+		schema["additionalProperties"] = g.convert(*t.AdditionalProperties.Type)
+	} else {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+func (g *generator) discriminatedObjectSchema(t *types.DiscriminatedObjectType, isRoot bool) map[string]interface{} {
+	oneOf := make([]interface{}, 0)
+	for _, key := range sortedKeys(t.Elements) {
+		properties, required := g.propertiesSchema(t.BaseProperties)
+		element, _ := referenceType(t.Elements[key]).(*types.ObjectType)
+		var additionalProperties interface{} = false
+		if element != nil {
+			elementProperties, elementRequired := g.propertiesSchema(element.Properties)
+			for name, value := range elementProperties {
+				properties[name] = value
+			}
+			required = append(required, elementRequired...)
+			if element.AdditionalProperties != nil && element.AdditionalProperties.Type != nil {
+				additionalProperties = g.convert(*element.AdditionalProperties.Type)
+			}
+		}
+
+		discriminator := map[string]interface{}{
+			"type":  "string",
+			"const": key,
+		}
+		if value, ok := properties[t.Discriminator].(map[string]interface{}); ok && value["description"] != nil {
+			discriminator["description"] = value["description"]
+		}
+		properties[t.Discriminator] = discriminator
+		if isRoot {
+			required = remove(required, "name")
+		}
+		// the discriminator is always required, even it's the `name` of the resource body
+		required = append(remove(required, t.Discriminator), t.Discriminator)
+		sort.Strings(required)
+
+		oneOf = append(oneOf, map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": additionalProperties,
+		})
+	}
+
+	schema := map[string]interface{}{
+		"type": "object",
+	}
+	if len(oneOf) != 0 {
+		schema["oneOf"] = oneOf
+	}
+	return schema
+}
+
+// propertiesSchema returns the schemas of the properties and the names of the required properties, the read-only properties are never required.
+func (g *generator) propertiesSchema(input map[string]types.ObjectProperty) (map[string]interface{}, []string) {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	for _, name := range sortedKeys(input) {
+		property := input[name]
+		schema := g.convert(referenceType(property.Type))
+		if property.Description != nil && *property.Description != "" {
+			schema["description"] = *property.Description
+		}
+		if property.IsReadOnly() {
+			schema["readOnly"] = true
+		}
+		if property.IsWriteOnly() {
+			schema["writeOnly"] = true
+		}
+		properties[name] = schema
+		if property.IsRequired() && !property.IsReadOnly() {
+			required = append(required, name)
+		}
+	}
+	return properties, required
+}
+
+func builtInSchema(kind types.BuiltInTypeKind) map[string]interface{} {
+	switch kind {
+	case types.NULL:
+		return map[string]interface{}{"type": "null"}
+	case types.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case types.Int:
+		return map[string]interface{}{"type": "integer"}
+	case types.String, types.ResourceRef:
+		return map[string]interface{}{"type": "string"}
+	case types.Object:
+		return map[string]interface{}{"type": "object"}
+	case types.Array:
+		return map[string]interface{}{"type": "array"}
+	}
+	return map[string]interface{}{}
+}
+
+// stringLiteralValues returns the values if all the elements of the union are string literals, otherwise it returns nil.
+func stringLiteralValues(t *types.UnionType) []interface{} {
+	values := make([]interface{}, 0)
+	for _, element := range t.Elements {
+		literal, ok := referenceType(element).(*types.StringLiteralType)
+		if !ok {
+			return nil
+		}
+		values = append(values, literal.Value)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func typeName(t types.TypeBase) string {
+	switch v := t.(type) {
+	case *types.ObjectType:
+		return v.Name
+	case *types.DiscriminatedObjectType:
+		return v.Name
+	}
+	return ""
+}
+
+func referenceType(reference *types.TypeReference) types.TypeBase {
+	if reference == nil || reference.Type == nil {
+		return nil
+	}
+	return *reference.Type
+}
+
+func remove(input []string, value string) []string {
+	output := make([]string, 0)
+	for _, v := range input {
+		if v != value {
+			output = append(output, v)
+		}
+	}
+	return output
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,215 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func reference(t types.TypeBase) *types.TypeReference {
+	return &types.TypeReference{Type: &t}
+}
+
+func description(value string) *string {
+	return &value
+}
+
+func testResourceType() *types.ResourceType {
+	stringType := &types.BuiltInType{Kind: types.String}
+	intType := &types.BuiltInType{Kind: types.Int}
+	sku := &types.UnionType{
+		Elements: []*types.TypeReference{
+			reference(&types.StringLiteralType{Value: "Basic"}),
+			reference(&types.StringLiteralType{Value: "Premium"}),
+		},
+	}
+	// a recursive type, the rule's children are rules
+	rule := &types.ObjectType{
+		Name: "Rule",
+		Properties: map[string]types.ObjectProperty{
+			"value": {Type: reference(stringType)},
+		},
+	}
+	rule.Properties["children"] = types.ObjectProperty{Type: reference(&types.ArrayType{ItemType: reference(rule)})}
+	source := &types.DiscriminatedObjectType{
+		Name:          "Source",
+		Discriminator: "kind",
+		BaseProperties: map[string]types.ObjectProperty{
+			"priority": {Type: reference(intType), Flags: []types.ObjectPropertyFlag{types.Required}},
+		},
+		Elements: map[string]*types.TypeReference{
+			"File": reference(&types.ObjectType{
+				Name: "FileSource",
+				Properties: map[string]types.ObjectProperty{
+					"kind": {Type: reference(&types.StringLiteralType{Value: "File"}), Flags: []types.ObjectPropertyFlag{types.Required}, Description: description("The kind of the source.")},
+					"path": {Type: reference(stringType), Flags: []types.ObjectPropertyFlag{types.Required}},
+				},
+			}),
+			"Url": reference(&types.ObjectType{
+				Name: "UrlSource",
+				Properties: map[string]types.ObjectProperty{
+					"kind": {Type: reference(&types.StringLiteralType{Value: "Url"}), Flags: []types.ObjectPropertyFlag{types.Required}},
+					"url":  {Type: reference(stringType)},
+				},
+			}),
+		},
+	}
+	body := &types.ObjectType{
+		Name: "Microsoft.Test/tests",
+		Properties: map[string]types.ObjectProperty{
+			"name":     {Type: reference(stringType), Flags: []types.ObjectPropertyFlag{types.Required}},
+			"id":       {Type: reference(stringType), Flags: []types.ObjectPropertyFlag{types.ReadOnly}, Description: description("The resource id")},
+			"secret":   {Type: reference(stringType), Flags: []types.ObjectPropertyFlag{types.WriteOnly}},
+			"sku":      {Type: reference(sku), Flags: []types.ObjectPropertyFlag{types.Required}},
+			"rules":    {Type: reference(&types.ArrayType{ItemType: reference(rule)})},
+			"source":   {Type: reference(source)},
+			"tags":     {Type: reference(&types.ObjectType{Name: "Tags", AdditionalProperties: reference(stringType)})},
+			"resource": {Type: reference(&types.BuiltInType{Kind: types.ResourceRef})},
+		},
+	}
+	return &types.ResourceType{
+		Name: "Microsoft.Test/tests@2022-01-01",
+		Body: reference(body),
+	}
+}
+
+func Test_Generate(t *testing.T) {
+	document := Generate("Microsoft.Test/tests@2022-01-01", testResourceType())
+	data, err := json.Marshal(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actual map[string]interface{}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Microsoft.Test/tests@2022-01-01",
+  "$ref": "#/$defs/Microsoft.Test_tests",
+  "$defs": {
+    "Microsoft.Test_tests": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string", "readOnly": true, "description": "The resource id"},
+        "name": {"type": "string"},
+        "resource": {"type": "string"},
+        "rules": {"type": "array", "items": {"$ref": "#/$defs/Rule"}},
+        "secret": {"type": "string", "writeOnly": true},
+        "sku": {"type": "string", "enum": ["Basic", "Premium"]},
+        "source": {"$ref": "#/$defs/Source"},
+        "tags": {"$ref": "#/$defs/Tags"}
+      },
+      "required": ["sku"],
+      "additionalProperties": false
+    },
+    "Rule": {
+      "type": "object",
+      "properties": {
+        "children": {"type": "array", "items": {"$ref": "#/$defs/Rule"}},
+        "value": {"type": "string"}
+      },
+      "additionalProperties": false
+    },
+    "Source": {
+      "type": "object",
+      "oneOf": [
+        {
+          "type": "object",
+          "properties": {
+            "kind": {"type": "string", "const": "File", "description": "The kind of the source."},
+            "path": {"type": "string"},
+            "priority": {"type": "integer"}
+          },
+          "required": ["kind", "path", "priority"],
+          "additionalProperties": false
+        },
+        {
+          "type": "object",
+          "properties": {
+            "kind": {"type": "string", "const": "Url"},
+            "priority": {"type": "integer"},
+            "url": {"type": "string"}
+          },
+          "required": ["kind", "priority"],
+          "additionalProperties": false
+        }
+      ]
+    },
+    "Tags": {
+      "type": "object",
+      "additionalProperties": {"type": "string"}
+    }
+  }
+}`
+	var expectedDocument map[string]interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedDocument); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedDocument, actual) {
+		t.Fatalf("Expected %s but got %s", expected, string(data))
+	}
+}
+
+func Test_GenerateDiscriminatedResourceBody(t *testing.T) {
+	stringType := &types.BuiltInType{Kind: types.String}
+	body := &types.DiscriminatedObjectType{
+		Name:          "Microsoft.Test/tests/config",
+		Discriminator: "name",
+		BaseProperties: map[string]types.ObjectProperty{
+			"id": {Type: reference(stringType), Flags: []types.ObjectPropertyFlag{types.ReadOnly}},
+		},
+		Elements: map[string]*types.TypeReference{
+			"web": reference(&types.ObjectType{
+				Name: "web",
+				Properties: map[string]types.ObjectProperty{
+					"name": {Type: reference(&types.StringLiteralType{Value: "web"}), Flags: []types.ObjectPropertyFlag{types.Required}},
+				},
+			}),
+		},
+	}
+	document := Generate("", &types.ResourceType{Body: reference(body)})
+	defs := document["$defs"].(map[string]interface{})
+	definition := defs["Microsoft.Test_tests_config"].(map[string]interface{})
+	element := definition["oneOf"].([]interface{})[0].(map[string]interface{})
+	if required := element["required"].([]string); !reflect.DeepEqual(required, []string{"name"}) {
+		t.Fatalf("Expected required %v but got %v", []string{"name"}, required)
+	}
+	if _, ok := document["title"]; ok {
+		t.Fatalf("Expected no title but got %v", document["title"])
+	}
+}
+
+func Test_GenerateConstraints(t *testing.T) {
+	minLength, maxLength := 3, 10
+	minValue, maxValue := int64(1), int64(100)
+	testData := []struct {
+		Type     types.TypeBase
+		Expected map[string]interface{}
+	}{
+		{
+			Type:     &types.StringType{MinLength: &minLength, MaxLength: &maxLength, Pattern: "^[a-z]+$"},
+			Expected: map[string]interface{}{"type": "string", "minLength": 3, "maxLength": 10, "pattern": "^[a-z]+$"},
+		},
+		{
+			Type:     &types.IntegerType{MinValue: &minValue, MaxValue: &maxValue},
+			Expected: map[string]interface{}{"type": "integer", "minimum": int64(1), "maximum": int64(100)},
+		},
+		{
+			Type:     &types.ArrayType{ItemType: reference(&types.IntegerType{}), MinLength: &minLength},
+			Expected: map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}, "minItems": 3},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %v", v.Expected)
+		actual := Generate("", v.Type)
+		delete(actual, "$schema")
+		if !reflect.DeepEqual(v.Expected, actual) {
+			t.Fatalf("Expected %v but got %v", v.Expected, actual)
+		}
+	}
+}
@@ -1,67 +1,137 @@
 package azure
 
 import (
-	"embed"
-	"encoding/json"
+	_ "embed"
 	"fmt"
 	"log"
-	"sort"
 	"strings"
+	"sync"
 
+	"github.com/Azure/terraform-provider-azapi/internal/azure/compiled"
 	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
 )
 
-var schema *Schema
+var (
+	schema     *Schema
+	schemaOnce sync.Once
+	// schemaMutex guards the resources and the functions of the schema, they're changed when the external schemas are loaded
+	schemaMutex sync.RWMutex
+	// archive contains the embedded type files, it's opened with the schema index
+	archive *compiled.Archive
+)
 
-//go:embed generated
-var StaticFiles embed.FS
+// The embedded schemas are compiled from the bicep type files in the `generated` folder, run `go generate` after the type files are updated.
+//
+//go:generate go run ./compiled/generator -input generated -output compiled/schema.bin
+//go:embed compiled/schema.bin
+var compiledSchema []byte
 
+// GetAzureSchema returns the schema index, the embedded index is loaded on first use.
 func GetAzureSchema() *Schema {
-	if schema == nil {
-		data, err := StaticFiles.ReadFile("generated/index.json")
+	schemaOnce.Do(func() {
+		var err error
+		archive, err = compiled.Open(compiledSchema)
 		if err != nil {
 			log.Printf("[ERROR] failed to load schema index: %+v", err)
-			return nil
+			return
 		}
-		err = json.Unmarshal(data, &schema)
+		index, err := newSchema(archive.Index)
 		if err != nil {
-			log.Printf("[ERROR] failed to unmarshal schema index: %+v", err)
-			return nil
+			log.Printf("[ERROR] failed to load schema index: %+v", err)
+			return
 		}
-	}
+		schema = index
+	})
 	return schema
 }
 
+// GetApiVersions returns the api-versions of the resource type sorted by CompareApiVersions, the resource type is case-insensitive.
 func GetApiVersions(resourceType string) []string {
 	azureSchema := GetAzureSchema()
 	if azureSchema == nil {
 		return []string{}
 	}
-	res := make([]string, 0)
-	for key, value := range azureSchema.Resources {
-		if strings.EqualFold(key, resourceType) {
-			for _, v := range value.Definitions {
-				res = append(res, v.ApiVersion)
-			}
-		}
-	}
-	sort.Strings(res)
+	schemaMutex.RLock()
+	defer schemaMutex.RUnlock()
+	versions := azureSchema.index.versions(resourceType)
+	res := make([]string, len(versions))
+	copy(res, versions)
 	return res
 }
 
+// CompareApiVersions compares the api-versions like `2021-01-01` and `2021-01-01-preview` by their dates,
+// the preview api-version is earlier than the stable api-version of the same date.
+func CompareApiVersions(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	dateA, suffixA := splitApiVersion(a)
+	dateB, suffixB := splitApiVersion(b)
+	switch {
+	case dateA != dateB:
+		return strings.Compare(dateA, dateB)
+	case suffixA == suffixB:
+		return 0
+	case suffixA == "":
+		return 1
+	case suffixB == "":
+		return -1
+	}
+	return strings.Compare(suffixA, suffixB)
+}
+
+// IsPreviewApiVersion returns whether the api-version has a suffix like `-preview` or `-beta`.
+func IsPreviewApiVersion(apiVersion string) bool {
+	_, suffix := splitApiVersion(apiVersion)
+	return suffix != ""
+}
+
+func splitApiVersion(apiVersion string) (string, string) {
+	// the date is like `2021-01-01`
+	if len(apiVersion) > 10 && apiVersion[10] == '-' {
+		return apiVersion[0:10], apiVersion[11:]
+	}
+	return apiVersion, ""
+}
+
+// GetResourceDefinition returns the definition of the resource type and the api-version, both are case-insensitive.
 func GetResourceDefinition(resourceType, apiVersion string) (*types.ResourceType, error) {
+	definition := findResourceDefinition(resourceType, apiVersion)
+	if definition == nil {
+		if GetAzureSchema() == nil {
+			return nil, fmt.Errorf("failed to load azure schema index")
+		}
+		if len(GetApiVersions(resourceType)) == 0 {
+			return nil, fmt.Errorf("failed to find resource type %s in azure schema index.%s", resourceType, suggestionMessage(SuggestResourceTypes(resourceType)))
+		}
+		return nil, fmt.Errorf("failed to find resource type %s api-version %s in azure schema index.%s", resourceType, apiVersion, suggestionMessage(SuggestApiVersions(resourceType, apiVersion)))
+	}
+	return definition.GetDefinition()
+}
+
+// findResourceDefinition returns a copy of the resource definition from the lookup index, so it could be used without holding the lock.
+func findResourceDefinition(resourceType, apiVersion string) *ResourceDefinition {
 	azureSchema := GetAzureSchema()
 	if azureSchema == nil {
-		return nil, fmt.Errorf("failed to load azure schema index")
+		return nil
 	}
-	for key, value := range azureSchema.Resources {
-		if strings.EqualFold(key, resourceType) {
-			for _, v := range value.Definitions {
-				if v.ApiVersion == apiVersion {
-					return v.GetDefinition()
-				}
-			}
-		}
+	schemaMutex.RLock()
+	defer schemaMutex.RUnlock()
+	if definition, ok := azureSchema.index.definition(resourceType, apiVersion); ok {
+		return &definition
 	}
-	return nil, fmt.Errorf("failed to find resource type %s api-version %s in azure schema index", resourceType, apiVersion)
+	return nil
+}
+
+// GetResourceTypes returns the resource types in the schema index.
+func GetResourceTypes() []string {
+	azureSchema := GetAzureSchema()
+	if azureSchema == nil {
+		return []string{}
+	}
+	schemaMutex.RLock()
+	defer schemaMutex.RUnlock()
+	res := make([]string, 0, len(azureSchema.Resources))
+	for key := range azureSchema.Resources {
+		res = append(res, key)
+	}
+	return res
 }
@@ -1,6 +1,8 @@
 package azure_test
 
 import (
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure"
@@ -24,6 +26,52 @@ func Test_GetApiVersions(t *testing.T) {
 	}
 }
 
+func Test_GetApiVersionsSorted(t *testing.T) {
+	versions := azure.GetApiVersions("Microsoft.MachineLearningServices/workspaces/computes")
+	for i := 1; i < len(versions); i++ {
+		if azure.CompareApiVersions(versions[i-1], versions[i]) >= 0 {
+			t.Fatalf("Expected %s to be earlier than %s", versions[i-1], versions[i])
+		}
+	}
+}
+
+func Test_CompareApiVersions(t *testing.T) {
+	testData := []struct {
+		A        string
+		B        string
+		Expected int
+	}{
+		{
+			A:        "2021-01-01",
+			B:        "2021-02-01",
+			Expected: -1,
+		},
+		{
+			// the preview is earlier than the stable api-version of the same date
+			A:        "2021-01-01",
+			B:        "2021-01-01-preview",
+			Expected: 1,
+		},
+		{
+			A:        "2021-01-01-beta",
+			B:        "2021-01-01-preview",
+			Expected: -1,
+		},
+		{
+			A:        "2021-01-01-Preview",
+			B:        "2021-01-01-preview",
+			Expected: 0,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s %s", v.A, v.B)
+		if actual := azure.CompareApiVersions(v.A, v.B); actual != v.Expected {
+			t.Fatalf("Expected %d but got %d", v.Expected, actual)
+		}
+	}
+}
+
 func Test_GetResourceDefinition(t *testing.T) {
 	case1 := "Microsoft.MachineLearningServices/workspaces/computes"
 	versions := azure.GetApiVersions(case1)
@@ -37,3 +85,151 @@ func Test_GetResourceDefinition(t *testing.T) {
 		}
 	}
 }
+
+func Test_GetResourceDefinitionCaseInsensitive(t *testing.T) {
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Error        bool
+	}{
+		{
+			ResourceType: "Microsoft.MachineLearningServices/workspaces/computes",
+			ApiVersion:   "2022-01-01-preview",
+			Error:        false,
+		},
+		{
+			ResourceType: "microsoft.machinelearningservices/WORKSPACES/computes",
+			ApiVersion:   "2022-01-01-PREVIEW",
+			Error:        false,
+		},
+		{
+			ResourceType: "Microsoft.MachineLearningServices/workspaces/computes",
+			ApiVersion:   "2022-01-02-preview",
+			Error:        true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s", v.ResourceType, v.ApiVersion)
+		def, err := azure.GetResourceDefinition(v.ResourceType, v.ApiVersion)
+		if v.Error != (err != nil) {
+			t.Fatalf("Expected error %t but got %v", v.Error, err)
+		}
+		if !v.Error && def == nil {
+			t.Fatalf("Expected a resource definition but got nil")
+		}
+		if err := azure.ValidateResourceType(v.ResourceType, v.ApiVersion); v.Error != (err != nil) {
+			t.Fatalf("Expected validation error %t but got %v", v.Error, err)
+		}
+	}
+
+	if versions := azure.GetApiVersions("MICROSOFT.MACHINELEARNINGSERVICES/WORKSPACES/COMPUTES"); len(versions) == 0 {
+		t.Fatalf("Expected the api-versions of the upper-cased resource type")
+	}
+}
+
+func Test_ValidateResourceTypeSuggestions(t *testing.T) {
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Expected     string
+	}{
+		{
+			ResourceType: "Microsoft.MachineLearningServices/workspace/computes",
+			ApiVersion:   "2021-07-01",
+			Expected:     "Do you mean `Microsoft.MachineLearningServices/workspaces/computes`",
+		},
+		{
+			ResourceType: "Microsoft.MachineLearningServices/workspaces/computes",
+			ApiVersion:   "2021-07-02",
+			Expected:     "Do you mean `2021-07-01`",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s", v.ResourceType, v.ApiVersion)
+		err := azure.ValidateResourceType(v.ResourceType, v.ApiVersion)
+		if err == nil || !strings.Contains(err.Error(), v.Expected) {
+			t.Fatalf("Expected an error which contains %q but got %v", v.Expected, err)
+		}
+		_, err = azure.GetResourceDefinition(v.ResourceType, v.ApiVersion)
+		if err == nil || !strings.Contains(err.Error(), v.Expected) {
+			t.Fatalf("Expected an error which contains %q but got %v", v.Expected, err)
+		}
+	}
+
+	if suggestions := azure.SuggestResourceTypes("Foo.Bar/baz"); len(suggestions) != 0 {
+		t.Fatalf("Expected no suggestion but got %v", suggestions)
+	}
+}
+
+// the resource types in the same type file
+var benchmarkResourceTypes = []string{
+	"Microsoft.Network/virtualNetworks",
+	"Microsoft.Network/virtualNetworks/subnets",
+	"Microsoft.Network/networkSecurityGroups",
+	"Microsoft.Network/publicIPAddresses",
+}
+
+const benchmarkApiVersion = "2021-02-01"
+
+func Test_GetResourceDefinitionConcurrency(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		for _, resourceType := range benchmarkResourceTypes {
+			wg.Add(1)
+			go func(resourceType string) {
+				defer wg.Done()
+				def, err := azure.GetResourceDefinition(resourceType, benchmarkApiVersion)
+				if err != nil || def == nil {
+					t.Errorf("failed to load resource definition for %s api-version %s: %v", resourceType, benchmarkApiVersion, err)
+				}
+			}(resourceType)
+		}
+	}
+	wg.Wait()
+}
+
+// BenchmarkLoadDefinition parses the type file for each definition, which is the behavior without the cache.
+func BenchmarkLoadDefinition(b *testing.B) {
+	locations := make([]azure.TypeLocation, 0)
+	for _, resourceType := range benchmarkResourceTypes {
+		for _, definition := range azure.GetAzureSchema().Resources[resourceType].Definitions {
+			if definition.ApiVersion == benchmarkApiVersion {
+				locations = append(locations, definition.Location)
+			}
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, location := range locations {
+			if _, err := location.LoadDefinition(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkGetResourceDefinition(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, resourceType := range benchmarkResourceTypes {
+			if _, err := azure.GetResourceDefinition(resourceType, benchmarkApiVersion); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkGetResourceDefinitionParallel(b *testing.B) {
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, resourceType := range benchmarkResourceTypes {
+				if _, err := azure.GetResourceDefinition(resourceType, benchmarkApiVersion); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
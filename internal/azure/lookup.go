@@ -0,0 +1,95 @@
+package azure
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+)
+
+// maxSuggestions is the max number of the suggested resource types or api-versions in the error messages.
+const maxSuggestions = 3
+
+// lookupIndex is the normalized index of the resource definitions, the resource types and the api-versions are case-insensitive.
+// The index contains copies of the resource definitions, so it must be rebuilt after the resources are changed.
+type lookupIndex struct {
+	// definitions maps the lower-cased resource types and the lower-cased api-versions to the resource definitions
+	definitions map[string]map[string]ResourceDefinition
+	// apiVersions maps the lower-cased resource types to the api-versions sorted by CompareApiVersions
+	apiVersions map[string][]string
+	// resourceTypes contains the resource types in the index, it's used for the suggestions
+	resourceTypes []string
+}
+
+func newLookupIndex(resources map[string]*Resource) *lookupIndex {
+	index := &lookupIndex{
+		definitions:   make(map[string]map[string]ResourceDefinition, len(resources)),
+		apiVersions:   make(map[string][]string, len(resources)),
+		resourceTypes: make([]string, 0, len(resources)),
+	}
+	// the same resource type may be in different cases in the index, their definitions are merged
+	for resourceType, resource := range resources {
+		key := strings.ToLower(resourceType)
+		if index.definitions[key] == nil {
+			index.definitions[key] = make(map[string]ResourceDefinition)
+			index.resourceTypes = append(index.resourceTypes, resourceType)
+		}
+		for _, definition := range resource.Definitions {
+			apiVersion := strings.ToLower(definition.ApiVersion)
+			if _, ok := index.definitions[key][apiVersion]; !ok {
+				index.apiVersions[key] = append(index.apiVersions[key], definition.ApiVersion)
+			}
+			index.definitions[key][apiVersion] = definition
+		}
+	}
+	for key := range index.apiVersions {
+		versions := index.apiVersions[key]
+		sort.Slice(versions, func(i, j int) bool {
+			return CompareApiVersions(versions[i], versions[j]) < 0
+		})
+	}
+	sort.Strings(index.resourceTypes)
+	return index
+}
+
+func (o *lookupIndex) definition(resourceType, apiVersion string) (ResourceDefinition, bool) {
+	definition, ok := o.definitions[strings.ToLower(resourceType)][strings.ToLower(apiVersion)]
+	return definition, ok
+}
+
+func (o *lookupIndex) versions(resourceType string) []string {
+	return o.apiVersions[strings.ToLower(resourceType)]
+}
+
+// buildIndex rebuilds the lookup index, it must be called with the lock held if the schema is shared.
+func (o *Schema) buildIndex() {
+	o.index = newLookupIndex(o.Resources)
+}
+
+// SuggestResourceTypes returns the resource types which are the most similar to the input.
+func SuggestResourceTypes(resourceType string) []string {
+	azureSchema := GetAzureSchema()
+	if azureSchema == nil {
+		return []string{}
+	}
+	schemaMutex.RLock()
+	defer schemaMutex.RUnlock()
+	return utils.ClosestMatches(resourceType, azureSchema.index.resourceTypes, len(resourceType)/3, maxSuggestions)
+}
+
+// SuggestApiVersions returns the api-versions of the resource type which are the most similar to the input.
+func SuggestApiVersions(resourceType, apiVersion string) []string {
+	return utils.ClosestMatches(apiVersion, GetApiVersions(resourceType), len(apiVersion)/2, maxSuggestions)
+}
+
+// suggestionMessage formats the suggestions like " Do you mean `a` or `b`?", it returns an empty string if there's no suggestion.
+func suggestionMessage(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	quoted := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		quoted = append(quoted, "`"+suggestion+"`")
+	}
+	return " Do you mean " + strings.Join(quoted, " or ") + "?"
+}
@@ -2,8 +2,10 @@ package tags
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func SchemaTagsOC() *schema.Schema {
@@ -91,3 +93,134 @@ func FlattenTags(input interface{}) map[string]interface{} {
 	}
 	return nil
 }
+
+func SchemaIgnoreTags() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"keys": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"key_prefixes": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func ExpandIgnoreTags(input []interface{}) (keys []string, keyPrefixes []string) {
+	keys = make([]string, 0)
+	keyPrefixes = make([]string, 0)
+	if len(input) == 0 || input[0] == nil {
+		return keys, keyPrefixes
+	}
+
+	v := input[0].(map[string]interface{})
+	if raw, ok := v["keys"].(*schema.Set); ok {
+		for _, key := range raw.List() {
+			keys = append(keys, key.(string))
+		}
+	}
+	if raw, ok := v["key_prefixes"].(*schema.Set); ok {
+		for _, prefix := range raw.List() {
+			keyPrefixes = append(keyPrefixes, prefix.(string))
+		}
+	}
+	return keys, keyPrefixes
+}
+
+// MergeTags merges the default tags and the resource tags, the resource tags take precedence.
+func MergeTags(defaultTags map[string]string, resourceTags map[string]string) map[string]string {
+	output := make(map[string]string, len(defaultTags)+len(resourceTags))
+	for k, v := range defaultTags {
+		output[k] = v
+	}
+	for k, v := range resourceTags {
+		output[k] = v
+	}
+	return output
+}
+
+// IgnoreTags removes the tags whose key is one of `keys` or starts with one of `keyPrefixes`.
+func IgnoreTags(input map[string]interface{}, keys []string, keyPrefixes []string) map[string]interface{} {
+	if input == nil {
+		return nil
+	}
+	output := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		if IsIgnoredTag(k, keys, keyPrefixes) {
+			continue
+		}
+		output[k] = v
+	}
+	return output
+}
+
+// MergeIgnoredTags returns the tags with the remote values of the ignored tags, so the tags managed outside of Terraform are kept
+// when the tags are updated. It returns nil if there are no tags at all.
+func MergeIgnoredTags(input map[string]string, remoteTags map[string]interface{}, keys []string, keyPrefixes []string) map[string]string {
+	output := make(map[string]string, len(input))
+	for k, v := range input {
+		output[k] = v
+	}
+	for k, v := range remoteTags {
+		if !IsIgnoredTag(k, keys, keyPrefixes) {
+			continue
+		}
+		if value, err := TagValueToString(v); err == nil {
+			output[k] = value
+		}
+	}
+	if input == nil && len(output) == 0 {
+		return nil
+	}
+	return output
+}
+
+// RemoveDefaultTags removes the tags which are inherited from the default tags, a tag is kept if it's also
+// defined in `configuredTags` or its value is different from the default one.
+func RemoveDefaultTags(input map[string]interface{}, defaultTags map[string]string, configuredTags map[string]interface{}) map[string]interface{} {
+	if input == nil {
+		return nil
+	}
+	output := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		if defaultValue, ok := defaultTags[k]; ok && v == defaultValue {
+			if _, ok := configuredTags[k]; !ok {
+				continue
+			}
+		}
+		output[k] = v
+	}
+	return output
+}
+
+// IsIgnoredTag returns whether the key is one of `keys` or starts with one of `keyPrefixes`.
+func IsIgnoredTag(key string, keys []string, keyPrefixes []string) bool {
+	for _, k := range keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range keyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
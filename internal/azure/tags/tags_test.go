@@ -0,0 +1,169 @@
+package tags_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/tags"
+)
+
+func Test_MergeTags(t *testing.T) {
+	cases := []struct {
+		DefaultTags  map[string]string
+		ResourceTags map[string]string
+		Expected     map[string]string
+	}{
+		{
+			DefaultTags:  nil,
+			ResourceTags: nil,
+			Expected:     map[string]string{},
+		},
+		{
+			DefaultTags:  map[string]string{"env": "prod"},
+			ResourceTags: nil,
+			Expected:     map[string]string{"env": "prod"},
+		},
+		{
+			DefaultTags:  map[string]string{"env": "prod", "owner": "platform"},
+			ResourceTags: map[string]string{"app": "web"},
+			Expected:     map[string]string{"env": "prod", "owner": "platform", "app": "web"},
+		},
+		{
+			DefaultTags:  map[string]string{"env": "prod", "owner": "platform"},
+			ResourceTags: map[string]string{"env": "dev"},
+			Expected:     map[string]string{"env": "dev", "owner": "platform"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %v and %v", tc.DefaultTags, tc.ResourceTags)
+		output := tags.MergeTags(tc.DefaultTags, tc.ResourceTags)
+
+		if !reflect.DeepEqual(tc.Expected, output) {
+			t.Fatalf("Expected %v but got %v", tc.Expected, output)
+		}
+	}
+}
+
+func Test_IgnoreTags(t *testing.T) {
+	cases := []struct {
+		Input       map[string]interface{}
+		Keys        []string
+		KeyPrefixes []string
+		Expected    map[string]interface{}
+	}{
+		{
+			Input:    nil,
+			Keys:     []string{"env"},
+			Expected: nil,
+		},
+		{
+			Input:    map[string]interface{}{"env": "prod", "app": "web"},
+			Expected: map[string]interface{}{"env": "prod", "app": "web"},
+		},
+		{
+			Input:    map[string]interface{}{"env": "prod", "app": "web"},
+			Keys:     []string{"env"},
+			Expected: map[string]interface{}{"app": "web"},
+		},
+		{
+			Input:       map[string]interface{}{"policy-owner": "a", "policy-costcenter": "b", "app": "web"},
+			KeyPrefixes: []string{"policy-"},
+			Expected:    map[string]interface{}{"app": "web"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %v", tc.Input)
+		output := tags.IgnoreTags(tc.Input, tc.Keys, tc.KeyPrefixes)
+
+		if !reflect.DeepEqual(tc.Expected, output) {
+			t.Fatalf("Expected %v but got %v", tc.Expected, output)
+		}
+	}
+}
+
+func Test_MergeIgnoredTags(t *testing.T) {
+	cases := []struct {
+		Input       map[string]string
+		RemoteTags  map[string]interface{}
+		Keys        []string
+		KeyPrefixes []string
+		Expected    map[string]string
+	}{
+		{
+			Input:    nil,
+			Keys:     []string{"env"},
+			Expected: nil,
+		},
+		{
+			// the remote tags which aren't ignored are overwritten
+			Input:       map[string]string{"app": "web"},
+			RemoteTags:  map[string]interface{}{"app": "api", "env": "prod", "policy-owner": "a"},
+			KeyPrefixes: []string{"policy-"},
+			Expected:    map[string]string{"app": "web", "policy-owner": "a"},
+		},
+		{
+			// the remote values of the ignored tags take precedence
+			Input:      map[string]string{"env": "dev"},
+			RemoteTags: map[string]interface{}{"env": "prod"},
+			Keys:       []string{"env"},
+			Expected:   map[string]string{"env": "prod"},
+		},
+		{
+			Input:      nil,
+			RemoteTags: map[string]interface{}{"env": "prod"},
+			Keys:       []string{"env"},
+			Expected:   map[string]string{"env": "prod"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %v", tc.Input)
+		output := tags.MergeIgnoredTags(tc.Input, tc.RemoteTags, tc.Keys, tc.KeyPrefixes)
+
+		if !reflect.DeepEqual(tc.Expected, output) {
+			t.Fatalf("Expected %v but got %v", tc.Expected, output)
+		}
+	}
+}
+
+func Test_RemoveDefaultTags(t *testing.T) {
+	cases := []struct {
+		Input          map[string]interface{}
+		DefaultTags    map[string]string
+		ConfiguredTags map[string]interface{}
+		Expected       map[string]interface{}
+	}{
+		{
+			Input:       nil,
+			DefaultTags: map[string]string{"env": "prod"},
+			Expected:    nil,
+		},
+		{
+			Input:       map[string]interface{}{"env": "prod", "app": "web"},
+			DefaultTags: map[string]string{"env": "prod"},
+			Expected:    map[string]interface{}{"app": "web"},
+		},
+		{
+			Input:       map[string]interface{}{"env": "dev", "app": "web"},
+			DefaultTags: map[string]string{"env": "prod"},
+			Expected:    map[string]interface{}{"env": "dev", "app": "web"},
+		},
+		{
+			Input:          map[string]interface{}{"env": "prod", "app": "web"},
+			DefaultTags:    map[string]string{"env": "prod"},
+			ConfiguredTags: map[string]interface{}{"env": "prod"},
+			Expected:       map[string]interface{}{"env": "prod", "app": "web"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %v", tc.Input)
+		output := tags.RemoveDefaultTags(tc.Input, tc.DefaultTags, tc.ConfiguredTags)
+
+		if !reflect.DeepEqual(tc.Expected, output) {
+			t.Fatalf("Expected %v but got %v", tc.Expected, output)
+		}
+	}
+}
@@ -11,7 +11,9 @@ import (
 var _ TypeBase = &ArrayType{}
 
 type ArrayType struct {
-	ItemType *TypeReference
+	ItemType  *TypeReference
+	MinLength *int
+	MaxLength *int
 }
 
 func (t *ArrayType) GetWriteOnly(body interface{}) interface{} {
@@ -52,6 +54,12 @@ func (t *ArrayType) Validate(body interface{}, path string) []error {
 		return errors
 	}
 
+	if t.MinLength != nil && len(bodyArray) < *t.MinLength {
+		errors = append(errors, utils.ErrorMinItems(path, *t.MinLength, len(bodyArray)))
+	}
+	if t.MaxLength != nil && len(bodyArray) > *t.MaxLength {
+		errors = append(errors, utils.ErrorMaxItems(path, *t.MaxLength, len(bodyArray)))
+	}
 	for index, value := range bodyArray {
 		if itemType != nil {
 			errors = append(errors, (*itemType).Validate(value, path+"."+strconv.Itoa(index))...)
@@ -72,14 +80,33 @@ func (t *ArrayType) UnmarshalJSON(body []byte) error {
 		return err
 	}
 	for k, v := range m {
-		if k == "ItemType" {
+		switch k {
+		case "ItemType", "itemType":
+			if v != nil {
+				var reference TypeReference
+				err := json.Unmarshal(*v, &reference)
+				if err != nil {
+					return err
+				}
+				t.ItemType = &reference
+			}
+		case "MinLength", "minLength":
+			if v != nil {
+				var minLength int
+				err := json.Unmarshal(*v, &minLength)
+				if err != nil {
+					return err
+				}
+				t.MinLength = &minLength
+			}
+		case "MaxLength", "maxLength":
 			if v != nil {
-				var index int
-				err := json.Unmarshal(*v, &index)
+				var maxLength int
+				err := json.Unmarshal(*v, &maxLength)
 				if err != nil {
 					return err
 				}
-				t.ItemType = &TypeReference{TypeIndex: index}
+				t.MaxLength = &maxLength
 			}
 		}
 	}
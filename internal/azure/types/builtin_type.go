@@ -58,6 +58,9 @@ func (t *BuiltInType) Validate(body interface{}, path string) []error {
 	}
 	errors := make([]error, 0)
 	kind := t.Kind
+	if kind == Any {
+		return errors
+	}
 	switch body.(type) {
 	case string:
 		if kind != String {
@@ -76,7 +79,7 @@ func (t *BuiltInType) Validate(body interface{}, path string) []error {
 			errors = append(errors, utils.ErrorMismatch(path, fmt.Sprint(kind), "object"))
 		}
 	case []interface{}:
-		if kind != String {
+		if kind != Array {
 			errors = append(errors, utils.ErrorMismatch(path, fmt.Sprint(kind), "array"))
 		}
 	default:
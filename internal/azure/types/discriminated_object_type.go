@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
 )
@@ -16,9 +17,12 @@ type DiscriminatedObjectType struct {
 	Elements       map[string]*TypeReference
 }
 
+// GetWriteOnly returns the write-only parts of the body, the element is selected by the discriminator.
+// If the discriminator is missing, the element which matches the body best is used. If the discriminator's value is unknown,
+// the properties which are not defined in the base properties are kept, except the ones which are read-only in all the elements defining them.
 func (t *DiscriminatedObjectType) GetWriteOnly(body interface{}) interface{} {
 	if t == nil || body == nil {
-		return []error{}
+		return nil
 	}
 	// check body type
 	bodyMap, ok := body.(map[string]interface{})
@@ -35,23 +39,71 @@ func (t *DiscriminatedObjectType) GetWriteOnly(body interface{}) interface{} {
 		}
 	}
 
-	if _, ok := bodyMap[t.Discriminator]; !ok {
-		return nil
-	}
-
+	var element *TypeBase
 	if discriminator, ok := bodyMap[t.Discriminator].(string); ok {
-		if t.Elements[discriminator].Type != nil {
-			if additionalProps := (*t.Elements[discriminator].Type).GetWriteOnly(body); additionalProps != nil {
-				if additionalMap, ok := additionalProps.(map[string]interface{}); ok {
-					for key, value := range additionalMap {
-						res[key] = value
-					}
-					return res
+		if t.Elements[discriminator] == nil {
+			for key, value := range bodyMap {
+				if _, ok := t.BaseProperties[key]; !ok && !t.isReadOnlyInElements(key) {
+					res[key] = value
 				}
 			}
+			return res
+		}
+		element = t.Elements[discriminator].Type
+	} else {
+		keys := make([]string, 0, len(t.Elements))
+		for key := range t.Elements {
+			keys = append(keys, key)
 		}
+		sort.Strings(keys)
+		candidates := make([]*TypeBase, 0)
+		for _, key := range keys {
+			if t.Elements[key] != nil && t.Elements[key].Type != nil {
+				candidates = append(candidates, t.Elements[key].Type)
+			}
+		}
+		// the elements are validated without the base properties, the same as the validation
+		otherProperties := make(map[string]interface{})
+		for key, value := range bodyMap {
+			if _, ok := t.BaseProperties[key]; !ok {
+				otherProperties[key] = value
+			}
+		}
+		element = bestMatch(candidates, otherProperties)
 	}
-	return nil
+
+	if element != nil {
+		if elementProps, ok := (*element).GetWriteOnly(body).(map[string]interface{}); ok {
+			for key, value := range elementProps {
+				res[key] = value
+			}
+		}
+	}
+	if value, ok := bodyMap[t.Discriminator]; ok {
+		res[t.Discriminator] = value
+	}
+	return res
+}
+
+// isReadOnlyInElements returns whether the property is defined in any element, and it's read-only in all the elements defining it.
+func (t *DiscriminatedObjectType) isReadOnlyInElements(property string) bool {
+	defined := false
+	for _, element := range t.Elements {
+		if element == nil || element.Type == nil {
+			continue
+		}
+		objectType, ok := (*element.Type).(*ObjectType)
+		if !ok {
+			continue
+		}
+		if def, ok := objectType.Properties[property]; ok {
+			if !def.IsReadOnly() {
+				return false
+			}
+			defined = true
+		}
+	}
+	return defined
 }
 
 func (t *DiscriminatedObjectType) Validate(body interface{}, path string) []error {
@@ -104,6 +156,7 @@ func (t *DiscriminatedObjectType) Validate(body interface{}, path string) []erro
 			for key := range t.Elements {
 				options = append(options, key)
 			}
+			sort.Strings(options)
 			errors = append(errors, utils.ErrorNotMatchAnyValues(path+"."+t.Discriminator, discriminator, options))
 		case t.Elements[discriminator].Type != nil:
 			errors = append(errors, (*t.Elements[discriminator].Type).Validate(otherProperties, path)...)
@@ -128,7 +181,7 @@ func (t *DiscriminatedObjectType) UnmarshalJSON(body []byte) error {
 	}
 	for k, v := range m {
 		switch k {
-		case "Name":
+		case "Name", "name":
 			if v != nil {
 				var name string
 				err := json.Unmarshal(*v, &name)
@@ -137,7 +190,7 @@ func (t *DiscriminatedObjectType) UnmarshalJSON(body []byte) error {
 				}
 				t.Name = name
 			}
-		case "Discriminator":
+		case "Discriminator", "discriminator":
 			if v != nil {
 				var discriminator string
 				err := json.Unmarshal(*v, &discriminator)
@@ -146,7 +199,7 @@ func (t *DiscriminatedObjectType) UnmarshalJSON(body []byte) error {
 				}
 				t.Discriminator = discriminator
 			}
-		case "BaseProperties":
+		case "BaseProperties", "baseProperties":
 			if v != nil {
 				var baseProperties map[string]ObjectProperty
 				err := json.Unmarshal(*v, &baseProperties)
@@ -155,17 +208,13 @@ func (t *DiscriminatedObjectType) UnmarshalJSON(body []byte) error {
 				}
 				t.BaseProperties = baseProperties
 			}
-		case "Elements":
+		case "Elements", "elements":
 			if v != nil {
-				var elementIndexes map[string]int
-				err := json.Unmarshal(*v, &elementIndexes)
+				var elements map[string]*TypeReference
+				err := json.Unmarshal(*v, &elements)
 				if err != nil {
 					return err
 				}
-				elements := make(map[string]*TypeReference)
-				for key, index := range elementIndexes {
-					elements[key] = &TypeReference{TypeIndex: index}
-				}
 				t.Elements = elements
 			}
 		}
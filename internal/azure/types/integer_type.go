@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+)
+
+var _ TypeBase = &IntegerType{}
+
+type IntegerType struct {
+	MinValue *int64 `json:"MinValue"`
+	MaxValue *int64 `json:"MaxValue"`
+}
+
+func (t *IntegerType) GetWriteOnly(body interface{}) interface{} {
+	if t == nil || body == nil {
+		return nil
+	}
+	return body
+}
+
+func (t *IntegerType) Validate(body interface{}, path string) []error {
+	if t == nil || body == nil {
+		return []error{}
+	}
+	errors := make([]error, 0)
+	var value float64
+	switch v := body.(type) {
+	case float64:
+		value = v
+	case float32:
+		value = float64(v)
+	case int:
+		value = float64(v)
+	case int32:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	default:
+		errors = append(errors, utils.ErrorMismatch(path, "int", fmt.Sprintf("%T", body)))
+		return errors
+	}
+	// the numbers in the json body are decoded as float64, so the fractional ones must be rejected explicitly
+	if value != math.Trunc(value) {
+		errors = append(errors, utils.ErrorMismatch(path, "int", fmt.Sprint(body)))
+		return errors
+	}
+	if t.MinValue != nil && value < float64(*t.MinValue) {
+		errors = append(errors, utils.ErrorMinValue(path, *t.MinValue, fmt.Sprint(body)))
+	}
+	if t.MaxValue != nil && value > float64(*t.MaxValue) {
+		errors = append(errors, utils.ErrorMaxValue(path, *t.MaxValue, fmt.Sprint(body)))
+	}
+	return errors
+}
+
+func (t *IntegerType) AsTypeBase() *TypeBase {
+	typeBase := TypeBase(t)
+	return &typeBase
+}
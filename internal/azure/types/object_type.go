@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
 )
@@ -13,6 +14,7 @@ type ObjectType struct {
 	Name                 string
 	Properties           map[string]ObjectProperty
 	AdditionalProperties *TypeReference
+	Sensitive            bool
 }
 
 func (t *ObjectType) GetWriteOnly(body interface{}) interface{} {
@@ -34,23 +36,18 @@ func (t *ObjectType) GetWriteOnly(body interface{}) interface{} {
 		}
 	}
 
-	// TODO: improve hardcode fix for userAssignedIdentities
-	if bodyMap["userAssignedIdentities"] != nil {
-		if userAssignedIdentitiesMap, ok := bodyMap["userAssignedIdentities"].(map[string]interface{}); ok {
-			uaMap := make(map[string]interface{})
-			for key := range userAssignedIdentitiesMap {
-				uaMap[key] = make(map[string]interface{})
-			}
-			res["userAssignedIdentities"] = uaMap
-		}
-	}
-
 	if t.AdditionalProperties != nil && t.AdditionalProperties.Type != nil {
-		if additionalProps := (*t.AdditionalProperties.Type).GetWriteOnly(body); additionalProps != nil {
-			if additionalMap, ok := additionalProps.(map[string]interface{}); ok {
-				for key, value := range additionalMap {
-					res[key] = value
-				}
+		valueType := *t.AdditionalProperties.Type
+		// the values which have no writable properties are still required as the keys are meaningful, like the user assigned identities
+		emptyValue := !hasWritableProperties(valueType)
+		for key, value := range bodyMap {
+			if _, ok := t.Properties[key]; ok {
+				continue
+			}
+			if emptyValue {
+				res[key] = make(map[string]interface{})
+			} else {
+				res[key] = valueType.GetWriteOnly(value)
 			}
 		}
 	}
@@ -86,9 +83,12 @@ func (t *ObjectType) Validate(body interface{}, path string) []error {
 			errors = append(errors, (*t.AdditionalProperties.Type).Validate(value, path+"."+key)...)
 		} else {
 			options := make([]string, 0)
-			for key := range t.Properties {
-				options = append(options, path+"."+key)
+			for key, def := range t.Properties {
+				if !def.IsReadOnly() {
+					options = append(options, key)
+				}
 			}
+			sort.Strings(options)
 			errors = append(errors, utils.ErrorShouldNotDefine(path+"."+key, options))
 		}
 	}
@@ -106,6 +106,20 @@ func (t *ObjectType) Validate(body interface{}, path string) []error {
 	return errors
 }
 
+// hasWritableProperties returns false if the type is an object type whose properties are all read-only.
+func hasWritableProperties(t TypeBase) bool {
+	object, ok := t.(*ObjectType)
+	if !ok || object == nil || object.AdditionalProperties != nil {
+		return true
+	}
+	for _, property := range object.Properties {
+		if !property.IsReadOnly() {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *ObjectType) AsTypeBase() *TypeBase {
 	typeBase := TypeBase(t)
 	return &typeBase
@@ -119,7 +133,7 @@ func (t *ObjectType) UnmarshalJSON(body []byte) error {
 	}
 	for k, v := range m {
 		switch k {
-		case "Name":
+		case "Name", "name":
 			if v != nil {
 				var name string
 				err := json.Unmarshal(*v, &name)
@@ -128,7 +142,7 @@ func (t *ObjectType) UnmarshalJSON(body []byte) error {
 				}
 				t.Name = name
 			}
-		case "Properties":
+		case "Properties", "properties":
 			if v != nil {
 				var properties map[string]ObjectProperty
 				err := json.Unmarshal(*v, &properties)
@@ -137,14 +151,23 @@ func (t *ObjectType) UnmarshalJSON(body []byte) error {
 				}
 				t.Properties = properties
 			}
-		case "AdditionalProperties":
+		case "AdditionalProperties", "additionalProperties":
 			if v != nil {
-				var index int
-				err := json.Unmarshal(*v, &index)
+				var reference TypeReference
+				err := json.Unmarshal(*v, &reference)
 				if err != nil {
 					return err
 				}
-				t.AdditionalProperties = &TypeReference{TypeIndex: index}
+				t.AdditionalProperties = &reference
+			}
+		case "Sensitive", "sensitive":
+			if v != nil {
+				var sensitive bool
+				err := json.Unmarshal(*v, &sensitive)
+				if err != nil {
+					return err
+				}
+				t.Sensitive = sensitive
 			}
 		}
 	}
@@ -176,6 +199,15 @@ func (o ObjectProperty) IsReadOnly() bool {
 	return false
 }
 
+func (o ObjectProperty) IsWriteOnly() bool {
+	for _, value := range o.Flags {
+		if value == WriteOnly {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *ObjectProperty) UnmarshalJSON(body []byte) error {
 	var m map[string]*json.RawMessage
 	err := json.Unmarshal(body, &m)
@@ -184,7 +216,7 @@ func (o *ObjectProperty) UnmarshalJSON(body []byte) error {
 	}
 	for k, v := range m {
 		switch k {
-		case "Description":
+		case "Description", "description":
 			if v != nil {
 				var description string
 				err := json.Unmarshal(*v, &description)
@@ -193,7 +225,7 @@ func (o *ObjectProperty) UnmarshalJSON(body []byte) error {
 				}
 				o.Description = &description
 			}
-		case "Flags":
+		case "Flags", "flags":
 			if v != nil {
 				var flag int
 				err := json.Unmarshal(*v, &flag)
@@ -208,14 +240,14 @@ func (o *ObjectProperty) UnmarshalJSON(body []byte) error {
 				}
 				o.Flags = flags
 			}
-		case "Type":
+		case "Type", "type":
 			if v != nil {
-				var index int
-				err := json.Unmarshal(*v, &index)
+				var reference TypeReference
+				err := json.Unmarshal(*v, &reference)
 				if err != nil {
 					return err
 				}
-				o.Type = &TypeReference{TypeIndex: index}
+				o.Type = &reference
 			}
 		}
 	}
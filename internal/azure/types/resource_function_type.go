@@ -33,7 +33,7 @@ func (t *ResourceFunctionType) UnmarshalJSON(body []byte) error {
 	}
 	for k, v := range m {
 		switch k {
-		case "Name":
+		case "Name", "name":
 			if v != nil {
 				var name string
 				err := json.Unmarshal(*v, &name)
@@ -42,7 +42,7 @@ func (t *ResourceFunctionType) UnmarshalJSON(body []byte) error {
 				}
 				t.Name = name
 			}
-		case "ResourceType":
+		case "ResourceType", "resourceType":
 			if v != nil {
 				var resourceType string
 				err := json.Unmarshal(*v, &resourceType)
@@ -51,7 +51,7 @@ func (t *ResourceFunctionType) UnmarshalJSON(body []byte) error {
 				}
 				t.ResourceType = resourceType
 			}
-		case "ApiVersion":
+		case "ApiVersion", "apiVersion":
 			if v != nil {
 				var apiVersion string
 				err := json.Unmarshal(*v, &apiVersion)
@@ -60,23 +60,23 @@ func (t *ResourceFunctionType) UnmarshalJSON(body []byte) error {
 				}
 				t.ApiVersion = apiVersion
 			}
-		case "Input":
+		case "Input", "input":
 			if v != nil {
-				var index int
-				err := json.Unmarshal(*v, &index)
+				var reference TypeReference
+				err := json.Unmarshal(*v, &reference)
 				if err != nil {
 					return err
 				}
-				t.Input = &TypeReference{TypeIndex: index}
+				t.Input = &reference
 			}
-		case "Output":
+		case "Output", "output":
 			if v != nil {
-				var index int
-				err := json.Unmarshal(*v, &index)
+				var reference TypeReference
+				err := json.Unmarshal(*v, &reference)
 				if err != nil {
 					return err
 				}
-				t.Output = &TypeReference{TypeIndex: index}
+				t.Output = &reference
 			}
 		}
 	}
@@ -7,9 +7,21 @@ import (
 var _ TypeBase = &ResourceType{}
 
 type ResourceType struct {
-	Name       string
+	Name string
+	// ScopeTypes are the scopes where the resource type can be read
 	ScopeTypes []ScopeType
-	Body       *TypeReference
+	// WritableScopeTypes are the scopes where the resource type can be created or updated, it's nil if the type file doesn't
+	// split the readable scopes and the writable scopes, then the resource type is writable in all of its scopes
+	WritableScopeTypes []ScopeType
+	Body               *TypeReference
+}
+
+// WritableScopes returns the scopes where the resource type can be created or updated.
+func (t *ResourceType) WritableScopes() []ScopeType {
+	if t.WritableScopeTypes != nil {
+		return t.WritableScopeTypes
+	}
+	return t.ScopeTypes
 }
 
 func (t *ResourceType) GetWriteOnly(body interface{}) interface{} {
@@ -44,9 +56,11 @@ func (t *ResourceType) UnmarshalJSON(body []byte) error {
 	if err != nil {
 		return err
 	}
+	scopeType := 0
+	var writableScopeType *int
 	for k, v := range m {
 		switch k {
-		case "Name":
+		case "Name", "name":
 			if v != nil {
 				var name string
 				err := json.Unmarshal(*v, &name)
@@ -55,38 +69,60 @@ func (t *ResourceType) UnmarshalJSON(body []byte) error {
 				}
 				t.Name = name
 			}
-		case "ScopeType":
+		case "ScopeType", "scopeType", "readableScopes":
 			if v != nil {
-				var scopeType int
-				err := json.Unmarshal(*v, &scopeType)
+				var value int
+				err := json.Unmarshal(*v, &value)
 				if err != nil {
 					return err
 				}
-				scopeTypes := make([]ScopeType, 0)
-				for _, f := range PossibleScopeTypeValues() {
-					if scopeType&int(f) != 0 {
-						scopeTypes = append(scopeTypes, f)
-					}
-				}
-				if scopeType == 0 {
-					scopeTypes = append(scopeTypes, Unknown)
+				scopeType |= value
+			}
+		// the current bicep-types format splits the scope type into the readable scopes and the writable scopes
+		case "writableScopes":
+			if v != nil {
+				var value int
+				err := json.Unmarshal(*v, &value)
+				if err != nil {
+					return err
 				}
-				t.ScopeTypes = scopeTypes
+				writableScopeType = &value
 			}
-		case "Body":
+		case "Body", "body":
 			if v != nil {
-				var index int
-				err := json.Unmarshal(*v, &index)
+				var reference TypeReference
+				err := json.Unmarshal(*v, &reference)
 				if err != nil {
 					return err
 				}
-				t.Body = &TypeReference{TypeIndex: index}
+				t.Body = &reference
 			}
 		}
 	}
+	// the resource type can be read in the scopes where it's writable
+	if writableScopeType != nil {
+		scopeType |= *writableScopeType
+		t.WritableScopeTypes = ScopeTypesOf(*writableScopeType)
+	}
+	scopeTypes := ScopeTypesOf(scopeType)
+	if scopeType == 0 {
+		scopeTypes = append(scopeTypes, Unknown)
+	}
+	t.ScopeTypes = scopeTypes
 	return nil
 }
 
+// ScopeTypesOf returns the scope types whose flags are set in the value.
+func ScopeTypesOf(value int) []ScopeType {
+	scopeTypes := make([]ScopeType, 0)
+	for _, f := range PossibleScopeTypeValues() {
+		if value&int(f) != 0 {
+			scopeTypes = append(scopeTypes, f)
+		}
+	}
+	return scopeTypes
+}
+
 type ScopeType int
 
 const (
@@ -2,12 +2,15 @@ package types
 
 import (
 	"encoding/json"
+	"log"
 )
 
 type Schema struct {
 	Types []*TypeBase
 }
 
+// UnmarshalJSON supports the types in the old bicep-types format like `{"2": {"Name": ...}}`, and in the current format like `{"$type": "ObjectType", "name": ...}`.
+// The types of unknown kinds are loaded as nil, so the indexes of the other types are kept.
 func (s *Schema) UnmarshalJSON(body []byte) error {
 	var m []map[string]*json.RawMessage
 	err := json.Unmarshal(body, &m)
@@ -16,70 +19,37 @@ func (s *Schema) UnmarshalJSON(body []byte) error {
 	}
 	types := make([]*TypeBase, 0)
 	for _, v := range m {
-		for _, typeBaseKind := range PossibleTypeBaseKindValues() {
-			if value := v[string(typeBaseKind)]; value != nil {
-				switch typeBaseKind {
-				case TypeBaseKindBuiltInType:
-					var t BuiltInType
-					err = json.Unmarshal(*value, &t)
-					if err != nil {
-						return err
-					}
-					types = append(types, t.AsTypeBase())
-				case TypeBaseKindObjectType:
-					var t ObjectType
-					err = json.Unmarshal(*value, &t)
-					if err != nil {
-						return err
-					}
-					types = append(types, t.AsTypeBase())
-				case TypeBaseKindArrayType:
-					var t ArrayType
-					err = json.Unmarshal(*value, &t)
-					if err != nil {
-						return err
-					}
-					types = append(types, t.AsTypeBase())
-				case TypeBaseKindResourceType:
-					var t ResourceType
-					err = json.Unmarshal(*value, &t)
-					if err != nil {
-						return err
-					}
-					types = append(types, t.AsTypeBase())
-				case TypeBaseKindUnionType:
-					var t UnionType
-					err = json.Unmarshal(*value, &t)
-					if err != nil {
-						return err
-					}
-					types = append(types, t.AsTypeBase())
-				case TypeBaseKindStringLiteralType:
-					var t StringLiteralType
-					err = json.Unmarshal(*value, &t)
-					if err != nil {
-						return err
-					}
-					types = append(types, t.AsTypeBase())
-				case TypeBaseKindDiscriminatedObjectType:
-					var t DiscriminatedObjectType
-					err = json.Unmarshal(*value, &t)
-					if err != nil {
-						return err
-					}
-					types = append(types, t.AsTypeBase())
-				case TypeBaseKindResourceFunctionType:
-					var t ResourceFunctionType
-					err = json.Unmarshal(*value, &t)
+		var typeBase *TypeBase
+		if value := v["$type"]; value != nil {
+			var kindName string
+			err = json.Unmarshal(*value, &kindName)
+			if err != nil {
+				return err
+			}
+			if kind, ok := typeBaseKindNames[kindName]; ok {
+				data, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				typeBase, err = unmarshalTypeBase(kind, data)
+				if err != nil {
+					return err
+				}
+			} else {
+				log.Printf("[WARN] unknown type kind %s", kindName)
+			}
+		} else {
+			for _, typeBaseKind := range PossibleTypeBaseKindValues() {
+				if value := v[string(typeBaseKind)]; value != nil {
+					typeBase, err = unmarshalTypeBase(typeBaseKind, *value)
 					if err != nil {
 						return err
 					}
-					types = append(types, t.AsTypeBase())
+					break
 				}
-				break
 			}
 		}
-
+		types = append(types, typeBase)
 	}
 	for index, v := range types {
 		if v != nil {
@@ -117,9 +87,52 @@ func (s *Schema) UnmarshalJSON(body []byte) error {
 				t.Input.UpdateType(types)
 				t.Output.UpdateType(types)
 				types[index] = t.AsTypeBase()
+			// the resource function types are stored as values, the references are pointers so they could be updated
+			case ResourceFunctionType:
+				t.Input.UpdateType(types)
+				t.Output.UpdateType(types)
 			}
 		}
 	}
 	s.Types = types
 	return nil
 }
+
+func unmarshalTypeBase(kind TypeBaseKind, data []byte) (*TypeBase, error) {
+	var t TypeBase
+	switch kind {
+	case TypeBaseKindBuiltInType:
+		t = &BuiltInType{}
+	case TypeBaseKindObjectType:
+		t = &ObjectType{}
+	case TypeBaseKindArrayType:
+		t = &ArrayType{}
+	case TypeBaseKindResourceType:
+		t = &ResourceType{}
+	case TypeBaseKindUnionType:
+		t = &UnionType{}
+	case TypeBaseKindStringLiteralType:
+		t = &StringLiteralType{}
+	case TypeBaseKindDiscriminatedObjectType:
+		t = &DiscriminatedObjectType{}
+	case TypeBaseKindResourceFunctionType:
+		t = &ResourceFunctionType{}
+	case TypeBaseKindIntegerType:
+		t = &IntegerType{}
+	case TypeBaseKindStringType:
+		t = &StringType{}
+	// the any, null and boolean types are the built-in types in the old format
+	case TypeBaseKindAnyType:
+		return (&BuiltInType{Kind: Any}).AsTypeBase(), nil
+	case TypeBaseKindNullType:
+		return (&BuiltInType{Kind: NULL}).AsTypeBase(), nil
+	case TypeBaseKindBooleanType:
+		return (&BuiltInType{Kind: Bool}).AsTypeBase(), nil
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t.AsTypeBase(), nil
+}
@@ -0,0 +1,479 @@
+package types_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+)
+
+const constraintsSchema = `[
+  {"13": {"MinLength": 3, "MaxLength": 10, "Pattern": "^[a-z0-9]+$"}},
+  {"12": {"MinValue": 1, "MaxValue": 100}},
+  {"3": {"ItemType": 0, "MinLength": 1, "MaxLength": 2}},
+  {"13": {"Pattern": "^(?!-)[a-z]+$"}},
+  {"2": {"Name": "Test", "Properties": {"name": {"Type": 0, "Flags": 0}, "count": {"Type": 1, "Flags": 0}, "items": {"Type": 2, "Flags": 0}, "label": {"Type": 3, "Flags": 0}}}}
+]`
+
+func Test_SchemaConstraints(t *testing.T) {
+	var schema types.Schema
+	if err := json.Unmarshal([]byte(constraintsSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if len(schema.Types) != 5 {
+		t.Fatalf("Expected %d types but got %d", 5, len(schema.Types))
+	}
+	body := *schema.Types[4]
+
+	testData := []struct {
+		Body   string
+		Errors []string
+	}{
+		{
+			Body:   `{"name": "abc1", "count": 10, "items": ["abc"], "label": "abc"}`,
+			Errors: []string{},
+		},
+		{
+			Body:   `{"name": "ab"}`,
+			Errors: []string{"`name` is invalid, expect the length to be at least 3 but got 2"},
+		},
+		{
+			Body:   `{"name": "abcdefghijk"}`,
+			Errors: []string{"`name` is invalid, expect the length to be at most 10 but got 11"},
+		},
+		{
+			Body:   `{"name": "ABC"}`,
+			Errors: []string{"`name`'s value `ABC` is invalid, expect the value to match the pattern `^[a-z0-9]+$`"},
+		},
+		{
+			Body:   `{"name": 1}`,
+			Errors: []string{"`name` is invalid, expect `string` but got `float64`"},
+		},
+		{
+			Body:   `{"count": 0}`,
+			Errors: []string{"`count` is invalid, expect the value to be at least 1 but got 0"},
+		},
+		{
+			Body:   `{"count": 101}`,
+			Errors: []string{"`count` is invalid, expect the value to be at most 100 but got 101"},
+		},
+		{
+			Body:   `{"count": "1"}`,
+			Errors: []string{"`count` is invalid, expect `int` but got `string`"},
+		},
+		{
+			Body:   `{"count": 1.5}`,
+			Errors: []string{"`count` is invalid, expect `int` but got `1.5`"},
+		},
+		{
+			Body:   `{"count": 2.0}`,
+			Errors: []string{},
+		},
+		{
+			Body:   `{"items": []}`,
+			Errors: []string{"`items` is invalid, expect at least 1 items but got 0"},
+		},
+		{
+			Body:   `{"items": ["abc", "def", "ab"]}`,
+			Errors: []string{"`items` is invalid, expect at most 2 items but got 3", "`items.2` is invalid, expect the length to be at least 3 but got 2"},
+		},
+		{
+			// the patterns which are not supported by RE2 are skipped
+			Body:   `{"label": "-abc"}`,
+			Errors: []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Body)
+		var input interface{}
+		if err := json.Unmarshal([]byte(v.Body), &input); err != nil {
+			t.Fatal(err)
+		}
+		errors := body.Validate(input, "")
+		actual := make([]string, 0)
+		for _, err := range errors {
+			actual = append(actual, err.Error())
+		}
+		if strings.Join(actual, "\n") != strings.Join(v.Errors, "\n") {
+			t.Fatalf("Expected %q but got %q", v.Errors, actual)
+		}
+	}
+}
+
+const currentFormatSchema = `[
+  {"$type": "StringType"},
+  {"$type": "StringLiteralType", "value": "Microsoft.Test/tests"},
+  {"$type": "StringLiteralType", "value": "2022-01-01"},
+  {"$type": "UnknownType"},
+  {"$type": "ObjectType", "name": "Microsoft.Test/tests", "properties": {
+    "id": {"type": {"$ref": "#/0"}, "flags": 10, "description": "The resource id"},
+    "name": {"type": {"$ref": "#/0"}, "flags": 9},
+    "type": {"type": {"$ref": "#/1"}, "flags": 10},
+    "apiVersion": {"type": {"$ref": "#/2"}, "flags": 10},
+    "properties": {"type": {"$ref": "#/5"}, "flags": 0},
+    "tags": {"type": {"$ref": "#/11"}, "flags": 0}
+  }},
+  {"$type": "ObjectType", "name": "TestProperties", "properties": {
+    "enabled": {"type": {"$ref": "#/6"}, "flags": 0},
+    "count": {"type": {"$ref": "#/7"}, "flags": 1},
+    "mode": {"type": {"$ref": "#/8"}, "flags": 0},
+    "anything": {"type": {"$ref": "#/9"}, "flags": 0},
+    "nothing": {"type": {"$ref": "#/10"}, "flags": 0},
+    "source": {"type": {"$ref": "#/14"}, "flags": 0},
+    "secret": {"type": {"$ref": "#/17"}, "flags": 4}
+  }},
+  {"$type": "BooleanType"},
+  {"$type": "IntegerType", "minValue": 1},
+  {"$type": "UnionType", "elements": [{"$ref": "#/12"}, {"$ref": "#/13"}]},
+  {"$type": "AnyType"},
+  {"$type": "NullType"},
+  {"$type": "ObjectType", "name": "Tags", "properties": {}, "additionalProperties": {"$ref": "#/0"}},
+  {"$type": "StringLiteralType", "value": "Fast"},
+  {"$type": "StringLiteralType", "value": "Slow"},
+  {"$type": "DiscriminatedObjectType", "name": "Source", "discriminator": "kind", "baseProperties": {}, "elements": {"File": {"$ref": "#/15"}}},
+  {"$type": "ObjectType", "name": "FileSource", "properties": {"kind": {"type": {"$ref": "#/16"}, "flags": 1}, "paths": {"type": {"$ref": "#/18"}, "flags": 0}}},
+  {"$type": "StringLiteralType", "value": "File"},
+  {"$type": "StringType", "sensitive": true, "minLength": 8},
+  {"$type": "ArrayType", "itemType": {"$ref": "#/0"}, "maxLength": 1},
+  {"$type": "ResourceType", "name": "Microsoft.Test/tests@2022-01-01", "readableScopes": 8, "writableScopes": 8, "body": {"$ref": "#/4"}, "flags": 0},
+  {"$type": "ResourceFunctionType", "name": "listKeys", "resourceType": "Microsoft.Test/tests", "apiVersion": "2022-01-01", "output": {"$ref": "#/0"}}
+]`
+
+func Test_SchemaCurrentFormat(t *testing.T) {
+	var schema types.Schema
+	if err := json.Unmarshal([]byte(currentFormatSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if len(schema.Types) != 21 {
+		t.Fatalf("Expected %d types but got %d", 21, len(schema.Types))
+	}
+	if schema.Types[3] != nil {
+		t.Fatalf("Expected the unknown type to be nil but got %v", *schema.Types[3])
+	}
+	resourceType, ok := (*schema.Types[19]).(*types.ResourceType)
+	if !ok {
+		t.Fatalf("Expected a resource type but got %T", *schema.Types[19])
+	}
+	if len(resourceType.ScopeTypes) != 1 || resourceType.ScopeTypes[0] != types.ResourceGroup {
+		t.Fatalf("Expected scope types %v but got %v", []types.ScopeType{types.ResourceGroup}, resourceType.ScopeTypes)
+	}
+	if writable := resourceType.WritableScopes(); len(writable) != 1 || writable[0] != types.ResourceGroup {
+		t.Fatalf("Expected writable scope types %v but got %v", []types.ScopeType{types.ResourceGroup}, writable)
+	}
+	if secret, ok := (*schema.Types[17]).(*types.StringType); !ok || !secret.Sensitive {
+		t.Fatalf("Expected a sensitive string type but got %v", *schema.Types[17])
+	}
+
+	testData := []struct {
+		Body   string
+		Errors []string
+	}{
+		{
+			Body:   `{"properties": {"enabled": true, "count": 1, "mode": "Fast", "anything": [1], "source": {"kind": "File", "paths": ["a"]}, "secret": "12345678"}, "tags": {"a": "b"}}`,
+			Errors: []string{},
+		},
+		{
+			Body:   `{"id": "id", "properties": {"count": 1}}`,
+			Errors: []string{"`id` is not expected here, it's read only"},
+		},
+		{
+			Body:   `{"properties": {}}`,
+			Errors: []string{"`properties.count` is required, but no definition was found"},
+		},
+		{
+			Body:   `{"properties": {"count": 0, "enabled": "true"}}`,
+			Errors: []string{"`properties.count` is invalid, expect the value to be at least 1 but got 0", "`properties.enabled` is invalid, expect `bool` but got `string`"},
+		},
+		{
+			Body:   `{"properties": {"count": 1, "mode": "Medium"}}`,
+			Errors: []string{"`properties.mode`'s value `Medium` is invalid. The supported values are [Fast, Slow]. Do you mean `Fast`? "},
+		},
+		{
+			Body:   `{"properties": {"count": 1, "source": {"kind": "File", "paths": ["a", "b"]}, "secret": "1234"}}`,
+			Errors: []string{"`properties.secret` is invalid, expect the length to be at least 8 but got 4", "`properties.source.paths` is invalid, expect at most 1 items but got 2"},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Body)
+		var input interface{}
+		if err := json.Unmarshal([]byte(v.Body), &input); err != nil {
+			t.Fatal(err)
+		}
+		actual := make([]string, 0)
+		for _, err := range resourceType.Validate(input, "") {
+			actual = append(actual, err.Error())
+		}
+		sort.Strings(actual)
+		if strings.Join(actual, "\n") != strings.Join(v.Errors, "\n") {
+			t.Fatalf("Expected %q but got %q", v.Errors, actual)
+		}
+	}
+}
+
+func Test_ValidationErrors(t *testing.T) {
+	var schema types.Schema
+	if err := json.Unmarshal([]byte(currentFormatSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	resourceType := (*schema.Types[19]).(*types.ResourceType)
+
+	testData := []struct {
+		Body        string
+		Kind        utils.ValidationErrorKind
+		Path        string
+		Suggestions []string
+	}{
+		{
+			Body:        `{"properties": {"count": 1, "enabld": true}}`,
+			Kind:        utils.ValidationErrorNotExpected,
+			Path:        "properties.enabld",
+			Suggestions: []string{"properties.enabled"},
+		},
+		{
+			// the read-only properties are not suggested
+			Body:        `{"properties": {"count": 1}, "di": "id"}`,
+			Kind:        utils.ValidationErrorNotExpected,
+			Path:        "di",
+			Suggestions: nil,
+		},
+		{
+			Body:        `{"properties": {"count": 1, "mode": "Slo"}}`,
+			Kind:        utils.ValidationErrorNotMatchAny,
+			Path:        "properties.mode",
+			Suggestions: []string{"Slow"},
+		},
+		{
+			Body:        `{"properties": {"count": 1, "source": {"kind": "Files"}}}`,
+			Kind:        utils.ValidationErrorNotMatchAny,
+			Path:        "properties.source.kind",
+			Suggestions: []string{"File"},
+		},
+		{
+			Body:        `{"properties": {"count": 1, "source": {"kind": "File", "paths": [1]}}}`,
+			Kind:        utils.ValidationErrorMismatch,
+			Path:        "properties.source.paths.0",
+			Suggestions: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Body)
+		var input interface{}
+		if err := json.Unmarshal([]byte(v.Body), &input); err != nil {
+			t.Fatal(err)
+		}
+		errors := resourceType.Validate(input, "")
+		if len(errors) != 1 {
+			t.Fatalf("Expected 1 error but got %q", errors)
+		}
+		validationErr, ok := errors[0].(*utils.ValidationError)
+		if !ok {
+			t.Fatalf("Expected a validation error but got %T", errors[0])
+		}
+		if validationErr.Kind != v.Kind || validationErr.Path != v.Path || !reflect.DeepEqual(validationErr.Suggestions, v.Suggestions) {
+			t.Fatalf("Expected %q %q %q but got %q %q %q", v.Kind, v.Path, v.Suggestions, validationErr.Kind, validationErr.Path, validationErr.Suggestions)
+		}
+		for _, suggestion := range v.Suggestions {
+			if !strings.Contains(validationErr.Error(), "Do you mean `"+suggestion+"`") {
+				t.Fatalf("Expected the error message to contain the suggestion %q but got %q", suggestion, validationErr.Error())
+			}
+		}
+	}
+}
+
+const polymorphicSchema = `[
+  {"$type": "StringType"},
+  {"$type": "IntegerType"},
+  {"$type": "ObjectType", "name": "Cat", "properties": {"name": {"type": {"$ref": "#/0"}, "flags": 1}, "lives": {"type": {"$ref": "#/1"}, "flags": 0}, "id": {"type": {"$ref": "#/0"}, "flags": 2}}},
+  {"$type": "ObjectType", "name": "Dog", "properties": {"name": {"type": {"$ref": "#/0"}, "flags": 1}, "breed": {"type": {"$ref": "#/0"}, "flags": 0}, "etag": {"type": {"$ref": "#/0"}, "flags": 2}}},
+  {"$type": "UnionType", "elements": [{"$ref": "#/2"}, {"$ref": "#/3"}, {"$ref": "#/0"}]},
+  {"$type": "DiscriminatedObjectType", "name": "Pet", "discriminator": "kind", "baseProperties": {"id": {"type": {"$ref": "#/0"}, "flags": 2}}, "elements": {"Cat": {"$ref": "#/2"}, "Dog": {"$ref": "#/3"}}}
+]`
+
+func Test_WriteOnlyPolymorphic(t *testing.T) {
+	var schema types.Schema
+	if err := json.Unmarshal([]byte(polymorphicSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		Type   int
+		Input  string
+		Output string
+	}{
+		{
+			Type:   4,
+			Input:  `{"name": "tom", "lives": 9, "id": "1"}`,
+			Output: `{"name": "tom", "lives": 9}`,
+		},
+		{
+			// the required property is missing in the response
+			Type:   4,
+			Input:  `{"breed": "husky", "etag": "1"}`,
+			Output: `{"breed": "husky"}`,
+		},
+		{
+			Type:   4,
+			Input:  `"tom"`,
+			Output: `"tom"`,
+		},
+		{
+			// none of the elements matches the body
+			Type:   4,
+			Input:  `[1]`,
+			Output: `[1]`,
+		},
+		{
+			Type:   5,
+			Input:  `{"kind": "Dog", "name": "max", "breed": "husky", "etag": "1", "id": "1"}`,
+			Output: `{"kind": "Dog", "name": "max", "breed": "husky"}`,
+		},
+		{
+			// the discriminator is missing
+			Type:   5,
+			Input:  `{"name": "tom", "lives": 9, "id": "1", "etag": "1"}`,
+			Output: `{"name": "tom", "lives": 9}`,
+		},
+		{
+			// the discriminator's value is unknown
+			Type:   5,
+			Input:  `{"kind": "Bird", "name": "tweety", "wings": 2, "id": "1"}`,
+			Output: `{"kind": "Bird", "name": "tweety", "wings": 2}`,
+		},
+		{
+			// the discriminator's value is unknown, the properties which are read-only in all the elements are dropped
+			Type:   5,
+			Input:  `{"kind": "Bird", "name": "tweety", "etag": "1", "lives": 1}`,
+			Output: `{"kind": "Bird", "name": "tweety", "lives": 1}`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Input)
+		var input, output interface{}
+		if err := json.Unmarshal([]byte(v.Input), &input); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(v.Output), &output); err != nil {
+			t.Fatal(err)
+		}
+		actual := (*schema.Types[v.Type]).GetWriteOnly(input)
+		if !reflect.DeepEqual(actual, output) {
+			t.Fatalf("Expected %v but got %v", output, actual)
+		}
+	}
+}
+
+const additionalPropertiesSchema = `[
+  {"$type": "StringType"},
+  {"$type": "ObjectType", "name": "UserAssignedIdentity", "properties": {"principalId": {"type": {"$ref": "#/0"}, "flags": 2}, "clientId": {"type": {"$ref": "#/0"}, "flags": 2}}},
+  {"$type": "ObjectType", "name": "UserAssignedIdentities", "properties": {}, "additionalProperties": {"$ref": "#/1"}},
+  {"$type": "ObjectType", "name": "Connection", "properties": {"id": {"type": {"$ref": "#/0"}, "flags": 2}, "status": {"type": {"$ref": "#/0"}, "flags": 0}}},
+  {"$type": "ObjectType", "name": "Body", "properties": {"type": {"type": {"$ref": "#/0"}, "flags": 0}, "etag": {"type": {"$ref": "#/0"}, "flags": 2}, "identities": {"type": {"$ref": "#/2"}, "flags": 0}, "clients": {"type": {"$ref": "#/6"}, "flags": 0}}, "additionalProperties": {"$ref": "#/3"}},
+  {"$type": "ObjectType", "name": "Client", "properties": {"principalId": {"type": {"$ref": "#/0"}, "flags": 2}, "clientId": {"type": {"$ref": "#/0"}, "flags": 0}}},
+  {"$type": "ObjectType", "name": "Clients", "properties": {}, "additionalProperties": {"$ref": "#/5"}}
+]`
+
+func Test_WriteOnlyAdditionalProperties(t *testing.T) {
+	var schema types.Schema
+	if err := json.Unmarshal([]byte(additionalPropertiesSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	body := *schema.Types[4]
+
+	testData := []struct {
+		Input  string
+		Output string
+	}{
+		{
+			// the values which only have read-only properties are empty objects
+			Input:  `{"type": "UserAssigned", "identities": {"/subscriptions/0/id1": {"principalId": "p1", "clientId": "c1"}, "/subscriptions/0/id2": {}}}`,
+			Output: `{"type": "UserAssigned", "identities": {"/subscriptions/0/id1": {}, "/subscriptions/0/id2": {}}}`,
+		},
+		{
+			// the values whose properties are all read-only in the schema are empty objects, whatever the properties are named
+			Input:  `{"identities": {"/subscriptions/0/id1": {"clientId": "c1"}}}`,
+			Output: `{"identities": {"/subscriptions/0/id1": {}}}`,
+		},
+		{
+			// the writable `clientId` is kept, only the read-only `principalId` is removed
+			Input:  `{"clients": {"client1": {"principalId": "p1", "clientId": "c1"}, "client2": {}}}`,
+			Output: `{"clients": {"client1": {"clientId": "c1"}, "client2": {}}}`,
+		},
+		{
+			Input:  `{"identities": {"/subscriptions/0/id1": null}}`,
+			Output: `{"identities": {"/subscriptions/0/id1": {}}}`,
+		},
+		{
+			// the values which have writable properties are extracted by the value type
+			Input:  `{"etag": "1", "connection1": {"id": "/subscriptions/0/connection1", "status": "Approved"}, "connection2": {"status": "Pending"}}`,
+			Output: `{"connection1": {"status": "Approved"}, "connection2": {"status": "Pending"}}`,
+		},
+		{
+			Input:  `{"type": "None", "identities": {}}`,
+			Output: `{"type": "None", "identities": {}}`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Input)
+		var input, output interface{}
+		if err := json.Unmarshal([]byte(v.Input), &input); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(v.Output), &output); err != nil {
+			t.Fatal(err)
+		}
+		actual := body.GetWriteOnly(input)
+		if !reflect.DeepEqual(actual, output) {
+			t.Fatalf("Expected %v but got %v", output, actual)
+		}
+	}
+}
+
+func Test_ResourceTypeScopes(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Readable []types.ScopeType
+		Writable []types.ScopeType
+	}{
+		{
+			// the legacy format doesn't split the scopes
+			Input:    `{"$type": "ResourceType", "name": "Microsoft.Test/tests@2022-01-01", "scopeType": 12}`,
+			Readable: []types.ScopeType{types.Subscription, types.ResourceGroup},
+			Writable: []types.ScopeType{types.Subscription, types.ResourceGroup},
+		},
+		{
+			Input:    `{"$type": "ResourceType", "name": "Microsoft.Test/tests@2022-01-01", "readableScopes": 12, "writableScopes": 8}`,
+			Readable: []types.ScopeType{types.Subscription, types.ResourceGroup},
+			Writable: []types.ScopeType{types.ResourceGroup},
+		},
+		{
+			// the read-only resource types
+			Input:    `{"$type": "ResourceType", "name": "Microsoft.Test/tests@2022-01-01", "readableScopes": 8, "writableScopes": 0}`,
+			Readable: []types.ScopeType{types.ResourceGroup},
+			Writable: []types.ScopeType{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Input)
+		var schema types.Schema
+		if err := json.Unmarshal([]byte("["+v.Input+"]"), &schema); err != nil {
+			t.Fatal(err)
+		}
+		resourceType := (*schema.Types[0]).(*types.ResourceType)
+		if !reflect.DeepEqual(resourceType.ScopeTypes, v.Readable) {
+			t.Fatalf("Expected readable scopes %v but got %v", v.Readable, resourceType.ScopeTypes)
+		}
+		if !reflect.DeepEqual(resourceType.WritableScopes(), v.Writable) {
+			t.Fatalf("Expected writable scopes %v but got %v", v.Writable, resourceType.WritableScopes())
+		}
+	}
+}
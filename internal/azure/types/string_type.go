@@ -0,0 +1,74 @@
+package types
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+)
+
+var _ TypeBase = &StringType{}
+
+type StringType struct {
+	Sensitive bool   `json:"Sensitive"`
+	MinLength *int   `json:"MinLength"`
+	MaxLength *int   `json:"MaxLength"`
+	Pattern   string `json:"Pattern"`
+}
+
+func (t *StringType) GetWriteOnly(body interface{}) interface{} {
+	if t == nil || body == nil {
+		return nil
+	}
+	return body
+}
+
+func (t *StringType) Validate(body interface{}, path string) []error {
+	if t == nil || body == nil {
+		return []error{}
+	}
+	errors := make([]error, 0)
+	stringValue, ok := body.(string)
+	if !ok {
+		errors = append(errors, utils.ErrorMismatch(path, "string", fmt.Sprintf("%T", body)))
+		return errors
+	}
+	length := utf8.RuneCountInString(stringValue)
+	if t.MinLength != nil && length < *t.MinLength {
+		errors = append(errors, utils.ErrorMinLength(path, *t.MinLength, length))
+	}
+	if t.MaxLength != nil && length > *t.MaxLength {
+		errors = append(errors, utils.ErrorMaxLength(path, *t.MaxLength, length))
+	}
+	if t.Pattern != "" {
+		if regex := compilePattern(t.Pattern); regex != nil && !regex.MatchString(stringValue) {
+			errors = append(errors, utils.ErrorPattern(path, t.Pattern, stringValue))
+		}
+	}
+	return errors
+}
+
+func (t *StringType) AsTypeBase() *TypeBase {
+	typeBase := TypeBase(t)
+	return &typeBase
+}
+
+// patterns caches the compiled patterns, the patterns which can't be compiled are cached as nil.
+var patterns sync.Map
+
+// compilePattern returns the compiled pattern, it returns nil if the pattern isn't supported by the RE2 syntax, for example, the lookaheads in the REST API specs.
+func compilePattern(pattern string) *regexp.Regexp {
+	if value, ok := patterns.Load(pattern); ok {
+		return value.(*regexp.Regexp)
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		log.Printf("[WARN] pattern %s is not supported, skip the validation: %+v", pattern, err)
+		regex = nil
+	}
+	patterns.Store(pattern, regex)
+	return regex
+}
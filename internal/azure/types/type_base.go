@@ -2,6 +2,7 @@ package types
 
 type TypeBase interface {
 	AsTypeBase() *TypeBase
+	// Validate returns the errors of the body, the errors are *utils.ValidationError which contain the paths of the invalid properties
 	Validate(interface{}, string) []error
 	GetWriteOnly(interface{}) interface{}
 }
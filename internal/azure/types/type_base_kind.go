@@ -11,6 +11,11 @@ const (
 	TypeBaseKindStringLiteralType       TypeBaseKind = "6"
 	TypeBaseKindDiscriminatedObjectType TypeBaseKind = "7"
 	TypeBaseKindResourceFunctionType    TypeBaseKind = "8"
+	TypeBaseKindAnyType                 TypeBaseKind = "9"
+	TypeBaseKindNullType                TypeBaseKind = "10"
+	TypeBaseKindBooleanType             TypeBaseKind = "11"
+	TypeBaseKindIntegerType             TypeBaseKind = "12"
+	TypeBaseKindStringType              TypeBaseKind = "13"
 )
 
 func PossibleTypeBaseKindValues() []TypeBaseKind {
@@ -23,5 +28,27 @@ func PossibleTypeBaseKindValues() []TypeBaseKind {
 		TypeBaseKindStringLiteralType,
 		TypeBaseKindDiscriminatedObjectType,
 		TypeBaseKindResourceFunctionType,
+		TypeBaseKindAnyType,
+		TypeBaseKindNullType,
+		TypeBaseKindBooleanType,
+		TypeBaseKindIntegerType,
+		TypeBaseKindStringType,
 	}
 }
+
+// typeBaseKindNames maps the `$type` values in the current bicep-types format to the kinds.
+var typeBaseKindNames = map[string]TypeBaseKind{
+	"BuiltInType":             TypeBaseKindBuiltInType,
+	"ObjectType":              TypeBaseKindObjectType,
+	"ArrayType":               TypeBaseKindArrayType,
+	"ResourceType":            TypeBaseKindResourceType,
+	"UnionType":               TypeBaseKindUnionType,
+	"StringLiteralType":       TypeBaseKindStringLiteralType,
+	"DiscriminatedObjectType": TypeBaseKindDiscriminatedObjectType,
+	"ResourceFunctionType":    TypeBaseKindResourceFunctionType,
+	"AnyType":                 TypeBaseKindAnyType,
+	"NullType":                TypeBaseKindNullType,
+	"BooleanType":             TypeBaseKindBooleanType,
+	"IntegerType":             TypeBaseKindIntegerType,
+	"StringType":              TypeBaseKindStringType,
+}
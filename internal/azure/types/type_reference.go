@@ -1,12 +1,52 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 type TypeReference struct {
 	Type      *TypeBase
 	TypeIndex int
 }
 
 func (t *TypeReference) UpdateType(types []*TypeBase) {
-	if t != nil {
+	if t != nil && t.TypeIndex >= 0 && t.TypeIndex < len(types) {
 		t.Type = types[t.TypeIndex]
 	}
 }
+
+// UnmarshalJSON supports the type index like `5` in the old bicep-types format, and the reference like `{"$ref": "#/5"}` in the current format.
+func (t *TypeReference) UnmarshalJSON(body []byte) error {
+	var index int
+	if err := json.Unmarshal(body, &index); err == nil {
+		t.TypeIndex = index
+		return nil
+	}
+	var reference struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(body, &reference); err != nil {
+		return err
+	}
+	index, err := ParseTypeIndex(reference.Ref)
+	if err != nil {
+		return err
+	}
+	t.TypeIndex = index
+	return nil
+}
+
+// ParseTypeIndex parses the index from a reference like `#/5`, the references to other files are not supported in a type file.
+func ParseTypeIndex(ref string) (int, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return 0, fmt.Errorf("type reference %q is invalid, expect a reference like `#/<index>`", ref)
+	}
+	index, err := strconv.Atoi(strings.TrimPrefix(ref, "#/"))
+	if err != nil {
+		return 0, fmt.Errorf("type reference %q is invalid: %+v", ref, err)
+	}
+	return index, nil
+}
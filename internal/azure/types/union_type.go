@@ -12,14 +12,54 @@ type UnionType struct {
 	Elements []*TypeReference
 }
 
+// GetWriteOnly returns the write-only parts of the body extracted by the element which matches the body best.
+// The body is returned unchanged if none of the elements matches it.
 func (t *UnionType) GetWriteOnly(body interface{}) interface{} {
 	if t == nil || body == nil {
 		return nil
 	}
-	// TODO: improve this
+	elements := make([]*TypeBase, 0)
+	for _, element := range t.Elements {
+		if element != nil && element.Type != nil {
+			elements = append(elements, element.Type)
+		}
+	}
+	if element := bestMatch(elements, body); element != nil {
+		if res := (*element).GetWriteOnly(body); res != nil {
+			return res
+		}
+	}
 	return body
 }
 
+// bestMatch returns the type which has the least mismatches with the body, it returns nil if every type mismatches the body's type.
+// The body could be a response which contains the read-only properties and misses the secrets, so the read-only and required errors are ignored.
+func bestMatch(candidates []*TypeBase, body interface{}) *TypeBase {
+	var res *TypeBase
+	minCount := -1
+	for _, candidate := range candidates {
+		count := 0
+		for _, err := range (*candidate).Validate(body, "") {
+			if validationErr, ok := err.(*utils.ValidationError); ok {
+				if validationErr.Kind == utils.ValidationErrorReadOnly || validationErr.Kind == utils.ValidationErrorRequired {
+					continue
+				}
+				// the body's type is different from the candidate's type
+				if validationErr.Kind == utils.ValidationErrorMismatch && validationErr.Path == "" {
+					count = -1
+					break
+				}
+			}
+			count++
+		}
+		if count != -1 && (minCount == -1 || count < minCount) {
+			res = candidate
+			minCount = count
+		}
+	}
+	return res
+}
+
 func (t *UnionType) Validate(body interface{}, path string) []error {
 	if t == nil || body == nil {
 		return []error{}
@@ -70,17 +110,13 @@ func (t *UnionType) UnmarshalJSON(body []byte) error {
 		return err
 	}
 	for k, v := range m {
-		if k == "Elements" {
+		if k == "Elements" || k == "elements" {
 			if v != nil {
-				var indexes []int
-				err := json.Unmarshal(*v, &indexes)
+				var elements []*TypeReference
+				err := json.Unmarshal(*v, &elements)
 				if err != nil {
 					return err
 				}
-				elements := make([]*TypeReference, 0)
-				for _, index := range indexes {
-					elements = append(elements, &TypeReference{TypeIndex: index})
-				}
 				t.Elements = elements
 			}
 		}
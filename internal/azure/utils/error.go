@@ -5,17 +5,63 @@ import (
 	"strings"
 )
 
+// ValidationErrorKind is the kind of the body validation error.
+type ValidationErrorKind string
+
+const (
+	// ValidationErrorMismatch means the value's type is not the expected type.
+	ValidationErrorMismatch ValidationErrorKind = "Mismatch"
+	// ValidationErrorNotMatchAny means the value doesn't match any element of a union type, or any accepted value of an enum.
+	ValidationErrorNotMatchAny ValidationErrorKind = "NotMatchAny"
+	// ValidationErrorReadOnly means the property is read-only.
+	ValidationErrorReadOnly ValidationErrorKind = "ReadOnly"
+	// ValidationErrorNotExpected means the property is not defined in the schema.
+	ValidationErrorNotExpected ValidationErrorKind = "NotExpected"
+	// ValidationErrorRequired means the required property is missing.
+	ValidationErrorRequired ValidationErrorKind = "Required"
+	// ValidationErrorConstraint means the value violates a constraint like the length limits, the value range or the pattern.
+	ValidationErrorConstraint ValidationErrorKind = "Constraint"
+	// ValidationErrorRule means the body violates a cross-property rule, like the identity type requires the user assigned identities.
+	ValidationErrorRule ValidationErrorKind = "Rule"
+	// ValidationErrorPolicy means the body violates a policy configured by the users.
+	ValidationErrorPolicy ValidationErrorKind = "Policy"
+)
+
+// ValidationError is an error of the body validation, the callers could use the path and the suggestions to render the error.
+type ValidationError struct {
+	Kind ValidationErrorKind
+	// Path is the dot-separated path of the invalid property in the body, like `properties.sku.name`, it's empty for the body itself
+	Path string
+	// Suggestions are the most similar property paths or values, they're already included in the error message
+	Suggestions []string
+	message     string
+}
+
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
+func newValidationError(kind ValidationErrorKind, key string, suggestions []string, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Kind:        kind,
+		Path:        strings.TrimPrefix(key, "."),
+		Suggestions: suggestions,
+		message:     fmt.Sprintf(format, args...),
+	}
+}
+
 func ErrorMismatch(key, expected, actual string) error {
-	return fmt.Errorf("`%s` is invalid, expect `%s` but got `%s`", strings.TrimPrefix(key, "."), expected, actual)
+	return newValidationError(ValidationErrorMismatch, key, nil, "`%s` is invalid, expect `%s` but got `%s`", strings.TrimPrefix(key, "."), expected, actual)
 }
 
 func ErrorNotMatchAny(key string) error {
-	return fmt.Errorf("`%s` doesn't match any accepted values", strings.TrimPrefix(key, "."))
+	return newValidationError(ValidationErrorNotMatchAny, key, nil, "`%s` doesn't match any accepted values", strings.TrimPrefix(key, "."))
 }
 
+// ErrorNotMatchAnyValues returns the error of an enum mismatch, it suggests the closest accepted value.
 func ErrorNotMatchAnyValues(key string, value string, options []string) error {
 	suggestion := getSuggestion(value, options)
-	return fmt.Errorf("`%s`'s value `%s` is invalid. The supported values are [%s]. Do you mean `%s`? ",
+	return newValidationError(ValidationErrorNotMatchAny, key, []string{suggestion}, "`%s`'s value `%s` is invalid. The supported values are [%s]. Do you mean `%s`? ",
 		strings.TrimPrefix(key, "."),
 		value,
 		strings.Join(options, ", "),
@@ -23,56 +69,65 @@ func ErrorNotMatchAnyValues(key string, value string, options []string) error {
 }
 
 func ErrorShouldNotDefineReadOnly(key string) error {
-	return fmt.Errorf("`%s` is not expected here, it's read only", strings.TrimPrefix(key, "."))
+	return newValidationError(ValidationErrorReadOnly, key, nil, "`%s` is not expected here, it's read only", strings.TrimPrefix(key, "."))
 }
 
+// ErrorShouldNotDefine returns the error of an unknown property, the options are the names of the properties defined in the same object.
+// It suggests the properties whose names are similar to the unknown property's name.
 func ErrorShouldNotDefine(key string, options []string) error {
-	suggestion := getSuggestion(key, options)
-	return fmt.Errorf("`%s` is not expected here. Do you mean `%s`? ", strings.TrimPrefix(key, "."), strings.TrimPrefix(suggestion, "."))
+	key = strings.TrimPrefix(key, ".")
+	parent, name := "", key
+	if index := strings.LastIndex(key, "."); index != -1 {
+		parent, name = key[0:index+1], key[index+1:]
+	}
+	suggestions := make([]string, 0)
+	for _, option := range ClosestMatches(name, options, max(2, len(name)/3), 3) {
+		suggestions = append(suggestions, parent+option)
+	}
+	if len(suggestions) == 0 {
+		return newValidationError(ValidationErrorNotExpected, key, nil, "`%s` is not expected here", key)
+	}
+	return newValidationError(ValidationErrorNotExpected, key, suggestions, "`%s` is not expected here. Do you mean `%s`? ", key, strings.Join(suggestions, "` or `"))
 }
 
 func ErrorShouldDefine(key string) error {
-	return fmt.Errorf("`%s` is required, but no definition was found", strings.TrimPrefix(key, "."))
+	return newValidationError(ValidationErrorRequired, key, nil, "`%s` is required, but no definition was found", strings.TrimPrefix(key, "."))
 }
 
-func getSuggestion(value string, options []string) string {
-	suggestion := ""
-	distance := 1 << 16
-	for _, option := range options {
-		if dist := editDistance(value, option); dist < distance {
-			distance = dist
-			suggestion = option
-		}
-	}
-	return suggestion
+func ErrorMinLength(key string, min int, actual int) error {
+	return newValidationError(ValidationErrorConstraint, key, nil, "`%s` is invalid, expect the length to be at least %d but got %d", strings.TrimPrefix(key, "."), min, actual)
 }
 
-func editDistance(a, b string) int {
-	n, m := len(a), len(b)
-	f := make([][]int, n+1)
-	for i := range f {
-		f[i] = make([]int, m+1, 1<<16)
-	}
-	for i := 1; i <= n; i++ {
-		for j := 1; j <= m; j++ {
-			f[i][j] = 1 << 16
-		}
-	}
-	for i := 1; i <= n; i++ {
-		for j := 1; j <= m; j++ {
-			if a[i-1] == b[j-1] {
-				f[i][j] = f[i-1][j-1]
-			}
-			if f[i][j] > f[i-1][j]+1 {
-				f[i][j] = f[i-1][j] + 1
-			}
-			if f[i][j] > f[i][j-1]+1 {
-				f[i][j] = f[i][j-1] + 1
-			}
-			if f[i][j] > f[i-1][j-1]+1 {
-				f[i][j] = f[i-1][j-1] + 1
-			}
-		}
-	}
-	return f[n][m]
+func ErrorMaxLength(key string, max int, actual int) error {
+	return newValidationError(ValidationErrorConstraint, key, nil, "`%s` is invalid, expect the length to be at most %d but got %d", strings.TrimPrefix(key, "."), max, actual)
+}
+
+func ErrorMinItems(key string, min int, actual int) error {
+	return newValidationError(ValidationErrorConstraint, key, nil, "`%s` is invalid, expect at least %d items but got %d", strings.TrimPrefix(key, "."), min, actual)
+}
+
+func ErrorMaxItems(key string, max int, actual int) error {
+	return newValidationError(ValidationErrorConstraint, key, nil, "`%s` is invalid, expect at most %d items but got %d", strings.TrimPrefix(key, "."), max, actual)
+}
+
+func ErrorMinValue(key string, min int64, actual string) error {
+	return newValidationError(ValidationErrorConstraint, key, nil, "`%s` is invalid, expect the value to be at least %d but got %s", strings.TrimPrefix(key, "."), min, actual)
+}
+
+func ErrorMaxValue(key string, max int64, actual string) error {
+	return newValidationError(ValidationErrorConstraint, key, nil, "`%s` is invalid, expect the value to be at most %d but got %s", strings.TrimPrefix(key, "."), max, actual)
+}
+
+func ErrorPattern(key string, pattern string, actual string) error {
+	return newValidationError(ValidationErrorConstraint, key, nil, "`%s`'s value `%s` is invalid, expect the value to match the pattern `%s`", strings.TrimPrefix(key, "."), actual, pattern)
+}
+
+// ErrorRule returns the error of a cross-property rule violation, the key is the path of the property which the rule points to.
+func ErrorRule(key string, rule string, message string) error {
+	return newValidationError(ValidationErrorRule, key, nil, "%s (rule `%s`)", message, rule)
+}
+
+// ErrorPolicy returns the error of a policy violation, the key is the path of the property which the policy points to.
+func ErrorPolicy(key string, policy string, message string) error {
+	return newValidationError(ValidationErrorPolicy, key, nil, "%s (policy `%s`)", message, policy)
 }
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// getSuggestion returns the option which is the most similar to the value.
+func getSuggestion(value string, options []string) string {
+	suggestion := ""
+	distance := -1
+	for _, option := range options {
+		if dist := editDistance(value, option); distance == -1 || dist < distance {
+			distance = dist
+			suggestion = option
+		}
+	}
+	return suggestion
+}
+
+// ClosestMatches returns at most limit options whose case-insensitive edit distances to the value are not greater than maxDistance.
+// The options are sorted by the distances, and the ones with the same distance are in the input order.
+func ClosestMatches(value string, options []string, maxDistance int, limit int) []string {
+	type match struct {
+		option   string
+		distance int
+	}
+	lowerValue := strings.ToLower(value)
+	matches := make([]match, 0)
+	for _, option := range options {
+		if dist := editDistance(lowerValue, strings.ToLower(option)); dist <= maxDistance {
+			matches = append(matches, match{option: option, distance: dist})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+	res := make([]string, 0, limit)
+	for i := 0; i < len(matches) && i < limit; i++ {
+		res = append(res, matches[i].option)
+	}
+	return res
+}
+
+// editDistance returns the levenshtein distance of the two strings, it only keeps two rows of the matrix.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j-1]+cost, previous[j]+1, current[j-1]+1)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,33 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+// ValidateResourceType checks whether the resource type and the api-version exist in the embedded schema, both are case-insensitive.
+// The error contains the most similar resource types or api-versions if they can't be found.
+func ValidateResourceType(resourceType, apiVersion string) error {
+	versions := GetApiVersions(resourceType)
+	if len(versions) == 0 {
+		return fmt.Errorf("the `type` is invalid, resource type %s can't be found.%s", resourceType, suggestionMessage(SuggestResourceTypes(resourceType)))
+	}
+	if findResourceDefinition(resourceType, apiVersion) != nil {
+		return nil
+	}
+	return fmt.Errorf("the `type`'s api-version is invalid. The supported versions are [%s].%s\n", strings.Join(versions, ", "), suggestionMessage(SuggestApiVersions(resourceType, apiVersion)))
+}
+
+// ValidateBody validates the body against the definition of the resource type and the api-version, it returns one error for each invalid property.
+func ValidateBody(resourceType, apiVersion string, body interface{}) []error {
+	if err := ValidateResourceType(resourceType, apiVersion); err != nil {
+		return []error{err}
+	}
+	resourceDef, err := GetResourceDefinition(resourceType, apiVersion)
+	if err != nil || resourceDef == nil {
+		return nil
+	}
+	return resourceDef.Validate(utils.NormalizeObject(body), "")
+}
@@ -433,6 +433,234 @@ func Test_WriteOnly(t *testing.T) {
 }
 `,
 		},
+		{
+			// the schema defines writable principalId and clientId for the user assigned identities, so they're kept
+			Id:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry1/tasks/task1",
+			ApiVersion: "2019-06-01-preview",
+			Input: `
+{
+    "identity": {
+        "type": "UserAssigned",
+        "userAssignedIdentities": {
+            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1": {
+                "clientId": "94d71dbe-168f-46ef-9f36-7661dc29b9dd",
+                "principalId": "9d812190-cc5f-4c92-8e09-b83af29c8568"
+            }
+        }
+    }
+}`,
+			Output: `
+{
+    "identity": {
+        "type": "UserAssigned",
+        "userAssignedIdentities": {
+            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1": {
+                "clientId": "94d71dbe-168f-46ef-9f36-7661dc29b9dd",
+                "principalId": "9d812190-cc5f-4c92-8e09-b83af29c8568"
+            }
+        }
+    }
+}`,
+		},
+	}
+
+	for _, data := range testData {
+		resourceType := utils.GetResourceType(data.Id)
+
+		var input, output interface{}
+		_ = json.Unmarshal([]byte(data.Input), &input)
+		_ = json.Unmarshal([]byte(data.Output), &output)
+
+		def, err := azure.GetResourceDefinition(resourceType, data.ApiVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if def != nil {
+			res := (*def).GetWriteOnly(input)
+			if !reflect.DeepEqual(res, output) {
+				resJson, _ := json.Marshal(res)
+				t.Errorf("expect %s got %s", data.Output, string(resJson))
+			}
+		} else {
+			t.Fatalf("failed to load resource definition for id: %s, api-version: %s", data.Id, data.ApiVersion)
+		}
+	}
+}
+
+func Test_WriteOnlyPolymorphic(t *testing.T) {
+	testData := []struct {
+		Id         string
+		ApiVersion string
+		Input      string
+		Output     string
+	}{
+		{
+			// the union of the string literals
+			Id:         "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.Insights/dataCollectionRules/myrule",
+			ApiVersion: "2021-04-01",
+			Input: `
+{
+    "id": "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.Insights/dataCollectionRules/myrule",
+    "name": "myrule",
+    "type": "Microsoft.Insights/dataCollectionRules",
+    "location": "westeurope",
+    "kind": "Linux",
+    "etag": "\"0a00f2b5-0000-0d00-0000-61fb9a6d0000\"",
+    "properties": {
+        "immutableId": "dcr-00000000000000000000000000000000",
+        "provisioningState": "Succeeded",
+        "dataSources": {
+            "syslog": [
+                {
+                    "name": "syslog",
+                    "streams": ["Microsoft-Syslog"],
+                    "facilityNames": ["auth", "cron"],
+                    "logLevels": ["*"]
+                }
+            ]
+        },
+        "destinations": {
+            "logAnalytics": [
+                {
+                    "name": "workspace",
+                    "workspaceId": "00000000-0000-0000-0000-000000000000",
+                    "workspaceResourceId": "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/myworkspace"
+                }
+            ]
+        },
+        "dataFlows": [
+            {
+                "streams": ["Microsoft-Syslog", "Microsoft-NewStream"],
+                "destinations": ["workspace"]
+            }
+        ]
+    }
+}`,
+			Output: `
+{
+    "name": "myrule",
+    "location": "westeurope",
+    "kind": "Linux",
+    "properties": {
+        "dataSources": {
+            "syslog": [
+                {
+                    "name": "syslog",
+                    "streams": ["Microsoft-Syslog"],
+                    "facilityNames": ["auth", "cron"],
+                    "logLevels": ["*"]
+                }
+            ]
+        },
+        "destinations": {
+            "logAnalytics": [
+                {
+                    "name": "workspace",
+                    "workspaceResourceId": "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/myworkspace"
+                }
+            ]
+        },
+        "dataFlows": [
+            {
+                "streams": ["Microsoft-Syslog", "Microsoft-NewStream"],
+                "destinations": ["workspace"]
+            }
+        ]
+    }
+}`,
+		},
+		{
+			// the element is selected by the discriminator
+			Id:         "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.Web/sites/mysite/config/logs",
+			ApiVersion: "2021-03-01",
+			Input: `
+{
+    "id": "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.Web/sites/mysite/config/logs",
+    "name": "logs",
+    "type": "Microsoft.Web/sites/config",
+    "kind": "app",
+    "properties": {
+        "detailedErrorMessages": {
+            "enabled": true
+        },
+        "httpLogs": {
+            "fileSystem": {
+                "enabled": true,
+                "retentionInMb": 35
+            }
+        }
+    }
+}`,
+			Output: `
+{
+    "name": "logs",
+    "kind": "app",
+    "properties": {
+        "detailedErrorMessages": {
+            "enabled": true
+        },
+        "httpLogs": {
+            "fileSystem": {
+                "enabled": true,
+                "retentionInMb": 35
+            }
+        }
+    }
+}`,
+		},
+		{
+			// the discriminator is missing, the element which matches the body best is used
+			Id:         "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.Web/sites/mysite/config/logs",
+			ApiVersion: "2021-03-01",
+			Input: `
+{
+    "id": "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.Web/sites/mysite/config/logs",
+    "type": "Microsoft.Web/sites/config",
+    "kind": "app",
+    "properties": {
+        "httpLogs": {
+            "fileSystem": {
+                "enabled": true,
+                "retentionInMb": 35
+            }
+        }
+    }
+}`,
+			Output: `
+{
+    "kind": "app",
+    "properties": {
+        "httpLogs": {
+            "fileSystem": {
+                "enabled": true,
+                "retentionInMb": 35
+            }
+        }
+    }
+}`,
+		},
+		{
+			// the discriminator's value is unknown, the body is kept
+			Id:         "/subscriptions/00000000-0000-0000-0000-00000000000/resourceGroups/rg/providers/Microsoft.Web/sites/mysite/config/newconfig",
+			ApiVersion: "2021-03-01",
+			Input: `
+{
+    "name": "newconfig",
+    "kind": "app",
+    "properties": {
+        "enabled": true
+    }
+}`,
+			Output: `
+{
+    "name": "newconfig",
+    "kind": "app",
+    "properties": {
+        "enabled": true
+    }
+}`,
+		},
 	}
 
 	for _, data := range testData {
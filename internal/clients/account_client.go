@@ -0,0 +1,95 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// Account contains the information of the principal which the provider is authenticated as.
+type Account struct {
+	TenantId       string
+	SubscriptionId string
+	ClientId       string
+	ObjectId       string
+	Environment    string
+	ARMEndpoint    string
+}
+
+type AccountClient struct {
+	subscriptionID string
+	environment    string
+	armEndpoint    arm.Endpoint
+	credential     azcore.TokenCredential
+}
+
+func NewAccountClient(subscriptionID string, environment string, credential azcore.TokenCredential, armEndpoint arm.Endpoint) *AccountClient {
+	if armEndpoint == "" {
+		armEndpoint = arm.AzurePublicCloud
+	}
+	return &AccountClient{
+		subscriptionID: subscriptionID,
+		environment:    environment,
+		armEndpoint:    armEndpoint,
+		credential:     credential,
+	}
+}
+
+// Get requests an access token for the resource manager and decodes its claims to build the Account.
+func (client *AccountClient) Get(ctx context.Context) (*Account, error) {
+	token, err := client.credential.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes: []string{fmt.Sprintf("%s/.default", strings.TrimSuffix(string(client.armEndpoint), "/"))},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("obtaining access token: %+v", err)
+	}
+	claims, err := parseTokenClaims(token.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	clientId := claims.AppId
+	if clientId == "" {
+		clientId = claims.AuthorizedParty
+	}
+	return &Account{
+		TenantId:       claims.TenantId,
+		SubscriptionId: client.subscriptionID,
+		ClientId:       clientId,
+		ObjectId:       claims.ObjectId,
+		Environment:    client.environment,
+		ARMEndpoint:    string(client.armEndpoint),
+	}, nil
+}
+
+type tokenClaims struct {
+	TenantId string `json:"tid"`
+	ObjectId string `json:"oid"`
+	// AppId is the client id in the v1 access token
+	AppId string `json:"appid"`
+	// AuthorizedParty is the client id in the v2 access token
+	AuthorizedParty string `json:"azp"`
+}
+
+// parseTokenClaims decodes the payload of the JWT access token, the signature is not verified because the token is only used to tell who the provider is authenticated as.
+func parseTokenClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("access token is not a valid JWT: expect 3 parts but got %d", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("decoding access token payload: %+v", err)
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("unmarshalling access token claims: %+v", err)
+	}
+	return &claims, nil
+}
@@ -0,0 +1,123 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeCredential struct {
+	token  string
+	err    error
+	scopes []string
+}
+
+func (c *fakeCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (*azcore.AccessToken, error) {
+	c.scopes = options.Scopes
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &azcore.AccessToken{Token: c.token, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func fakeToken(t *testing.T, claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	return fmt.Sprintf("%s.%s.signature", header, base64.RawURLEncoding.EncodeToString(payload))
+}
+
+func TestAccountClient(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Credential  *fakeCredential
+		ARMEndpoint arm.Endpoint
+		Expected    *Account
+		Scope       string
+		Error       bool
+	}{
+		{
+			Name: "v1 token",
+			Credential: &fakeCredential{token: fakeToken(t, map[string]interface{}{
+				"tid":   "00000000-0000-0000-0000-000000000001",
+				"oid":   "00000000-0000-0000-0000-000000000002",
+				"appid": "00000000-0000-0000-0000-000000000003",
+			})},
+			Expected: &Account{
+				TenantId:       "00000000-0000-0000-0000-000000000001",
+				ObjectId:       "00000000-0000-0000-0000-000000000002",
+				ClientId:       "00000000-0000-0000-0000-000000000003",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				Environment:    "public",
+				ARMEndpoint:    "https://management.azure.com/",
+			},
+			Scope: "https://management.azure.com/.default",
+		},
+		{
+			Name: "v2 token",
+			Credential: &fakeCredential{token: fakeToken(t, map[string]interface{}{
+				"tid": "00000000-0000-0000-0000-000000000001",
+				"oid": "00000000-0000-0000-0000-000000000002",
+				"azp": "00000000-0000-0000-0000-000000000003",
+			})},
+			ARMEndpoint: arm.AzureChina,
+			Expected: &Account{
+				TenantId:       "00000000-0000-0000-0000-000000000001",
+				ObjectId:       "00000000-0000-0000-0000-000000000002",
+				ClientId:       "00000000-0000-0000-0000-000000000003",
+				SubscriptionId: "00000000-0000-0000-0000-000000000000",
+				Environment:    "public",
+				ARMEndpoint:    "https://management.chinacloudapi.cn/",
+			},
+			Scope: "https://management.chinacloudapi.cn/.default",
+		},
+		{
+			Name:       "invalid token",
+			Credential: &fakeCredential{token: "invalid"},
+			Error:      true,
+		},
+		{
+			Name:       "invalid payload",
+			Credential: &fakeCredential{token: "header.!!!.signature"},
+			Error:      true,
+		},
+		{
+			Name:       "credential error",
+			Credential: &fakeCredential{err: fmt.Errorf("authentication failed")},
+			Error:      true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		client := NewAccountClient("00000000-0000-0000-0000-000000000000", "public", v.Credential, v.ARMEndpoint)
+		actual, err := client.Get(context.TODO())
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if *actual != *v.Expected {
+			t.Fatalf("Expected %+v but got %+v", *v.Expected, *actual)
+		}
+		if len(v.Credential.scopes) != 1 || v.Credential.scopes[0] != v.Scope {
+			t.Fatalf("Expected scopes [%s] but got %v", v.Scope, v.Credential.scopes)
+		}
+	}
+}
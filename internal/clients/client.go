@@ -18,13 +18,22 @@ type Client struct {
 
 	Features features.UserFeatures
 
+	SubscriptionId string
+
+	Environment string
+
 	ResourceClient *ResourceClient
+
+	DataPlaneClient *DataPlaneClient
+
+	AccountClient *AccountClient
 }
 
 type Option struct {
 	SubscriptionId           string
 	Cred                     azcore.TokenCredential
 	ARMEndpoint              arm.Endpoint
+	Environment              string
 	AuxiliaryTenantIDs       []string
 	ApplicationUserAgent     string
 	Features                 features.UserFeatures
@@ -36,27 +45,34 @@ type Option struct {
 func (client *Client) Build(ctx context.Context, o *Option) error {
 	client.StopContext = ctx
 	client.Features = o.Features
+	client.SubscriptionId = o.SubscriptionId
+	client.Environment = o.Environment
 
 	azlog.SetListener(func(cls azlog.Event, msg string) {
 		log.Printf("[DEBUG] %s %s: %s\n", time.Now().Format(time.StampMicro), cls, msg)
 	})
-	resourceClient := NewResourceClient(o.SubscriptionId, o.Cred, &arm.ClientOptions{
-		ClientOptions: policy.ClientOptions{
-			Telemetry: policy.TelemetryOptions{
-				ApplicationID: o.ApplicationUserAgent,
-			},
-			Logging: policy.LogOptions{
-				IncludeBody: true,
-			},
-			PerCallPolicies: []policy.Policy{
-				withCorrelationRequestID(correlationRequestID()),
-			},
+	clientOptions := policy.ClientOptions{
+		Telemetry: policy.TelemetryOptions{
+			ApplicationID: o.ApplicationUserAgent,
 		},
+		Logging: policy.LogOptions{
+			IncludeBody: true,
+		},
+		PerCallPolicies: []policy.Policy{
+			withCorrelationRequestID(correlationRequestID()),
+		},
+	}
+	resourceClient := NewResourceClient(o.SubscriptionId, o.Cred, &arm.ClientOptions{
+		ClientOptions:         clientOptions,
 		AuxiliaryTenants:      o.AuxiliaryTenantIDs,
 		DisableRPRegistration: o.SkipProviderRegistration,
 		Endpoint:              o.ARMEndpoint,
 	})
 	client.ResourceClient = resourceClient
 
+	client.DataPlaneClient = NewDataPlaneClient(o.Cred, &clientOptions)
+
+	client.AccountClient = NewAccountClient(o.SubscriptionId, o.Environment, o.Cred, o.ARMEndpoint)
+
 	return nil
 }
@@ -0,0 +1,136 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// DataPlaneClient sends requests to the data-plane endpoints, the access tokens are requested for the scope of each request.
+type DataPlaneClient struct {
+	credential azcore.TokenCredential
+	options    *policy.ClientOptions
+
+	mutex     sync.Mutex
+	pipelines map[string]runtime.Pipeline
+}
+
+// DataPlaneRequestOption contains the scope of the access token and the extra headers and query parameters of the request.
+type DataPlaneRequestOption struct {
+	Scope           string
+	Headers         map[string]string
+	QueryParameters map[string]string
+}
+
+func NewDataPlaneClient(credential azcore.TokenCredential, opt *policy.ClientOptions) *DataPlaneClient {
+	if opt == nil {
+		opt = &policy.ClientOptions{}
+	}
+	return &DataPlaneClient{
+		credential: credential,
+		options:    opt,
+		pipelines:  make(map[string]runtime.Pipeline),
+	}
+}
+
+func (client *DataPlaneClient) pipeline(scope string) runtime.Pipeline {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if pl, ok := client.pipelines[scope]; ok {
+		return pl
+	}
+	pl := runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{
+		PerRetry: []policy.Policy{runtime.NewBearerTokenPolicy(client.credential, []string{scope}, nil)},
+	}, client.options)
+	client.pipelines[scope] = pl
+	return pl
+}
+
+func (client *DataPlaneClient) CreateOrUpdate(ctx context.Context, url string, body interface{}, option DataPlaneRequestOption) (interface{}, *http.Response, error) {
+	req, err := client.createRequest(ctx, http.MethodPut, url, option)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := runtime.MarshalAsJSON(req, body); err != nil {
+		return nil, nil, err
+	}
+	// MarshalAsJSON overwrites the content type, the data-plane may require a specific one
+	if contentType, ok := option.Headers["Content-Type"]; ok {
+		req.Raw().Header.Set("Content-Type", contentType)
+	}
+	resp, err := client.pipeline(option.Scope).Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent) {
+		return nil, nil, runtime.NewResponseError(resp)
+	}
+	return unmarshalResponse(resp)
+}
+
+func (client *DataPlaneClient) Get(ctx context.Context, url string, option DataPlaneRequestOption) (interface{}, *http.Response, error) {
+	req, err := client.createRequest(ctx, http.MethodGet, url, option)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := client.pipeline(option.Scope).Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, nil, runtime.NewResponseError(resp)
+	}
+	return unmarshalResponse(resp)
+}
+
+func (client *DataPlaneClient) Delete(ctx context.Context, url string, option DataPlaneRequestOption) (interface{}, *http.Response, error) {
+	req, err := client.createRequest(ctx, http.MethodDelete, url, option)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := client.pipeline(option.Scope).Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusAccepted, http.StatusNoContent) {
+		return nil, nil, runtime.NewResponseError(resp)
+	}
+	return unmarshalResponse(resp)
+}
+
+func (client *DataPlaneClient) createRequest(ctx context.Context, method string, url string, option DataPlaneRequestOption) (*policy.Request, error) {
+	req, err := runtime.NewRequest(ctx, method, url)
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	for key, value := range option.QueryParameters {
+		reqQP.Set(key, value)
+	}
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header.Set("Accept", "application/json")
+	for key, value := range option.Headers {
+		req.Raw().Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// unmarshalResponse unmarshals the response body, it returns nil if the response body is empty.
+func unmarshalResponse(resp *http.Response) (interface{}, *http.Response, error) {
+	payload, err := runtime.Payload(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(payload) == 0 {
+		return nil, resp, nil
+	}
+	var responseBody interface{}
+	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
+		return nil, nil, err
+	}
+	return responseBody, resp, nil
+}
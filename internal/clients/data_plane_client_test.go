@@ -0,0 +1,100 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+func TestDataPlaneClient(t *testing.T) {
+	secrets := make(map[string]interface{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+fakeToken(t, nil) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("api-version") != "7.4" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			if r.Header.Get("Content-Type") != "application/vnd.test+json" {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			data, _ := io.ReadAll(r.Body)
+			var body interface{}
+			_ = json.Unmarshal(data, &body)
+			secrets[r.URL.Path] = body
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			body, ok := secrets[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			data, _ := json.Marshal(body)
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(secrets, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	credential := &fakeCredential{token: fakeToken(t, nil)}
+	client := NewDataPlaneClient(credential, &policy.ClientOptions{
+		Transport: server.Client(),
+		Retry: policy.RetryOptions{
+			MaxRetries: -1,
+		},
+	})
+	option := DataPlaneRequestOption{
+		Scope: "https://vault.azure.net/.default",
+		Headers: map[string]string{
+			"Content-Type": "application/vnd.test+json",
+		},
+		QueryParameters: map[string]string{
+			"api-version": "7.4",
+		},
+	}
+	url := server.URL + "/secrets/secret1"
+
+	if _, _, err := client.Get(context.TODO(), url, option); !utils.ResponseErrorWasNotFound(err) {
+		t.Fatalf("Expected not found error but got %+v", err)
+	}
+
+	body := map[string]interface{}{"value": "secret"}
+	output, _, err := client.CreateOrUpdate(context.TODO(), url, body, option)
+	if err != nil {
+		t.Fatalf("Expected no error but got %+v", err)
+	}
+	if output != nil {
+		t.Fatalf("Expected empty response but got %v", output)
+	}
+	if len(credential.scopes) != 1 || credential.scopes[0] != option.Scope {
+		t.Fatalf("Expected scopes [%s] but got %v", option.Scope, credential.scopes)
+	}
+
+	output, _, err = client.Get(context.TODO(), url, option)
+	if err != nil {
+		t.Fatalf("Expected no error but got %+v", err)
+	}
+	if value := output.(map[string]interface{})["value"]; value != "secret" {
+		t.Fatalf("Expected %s but got %v", "secret", value)
+	}
+
+	if _, _, err = client.Delete(context.TODO(), url, option); err != nil {
+		t.Fatalf("Expected no error but got %+v", err)
+	}
+	if _, _, err := client.Get(context.TODO(), url, option); !utils.ResponseErrorWasNotFound(err) {
+		t.Fatalf("Expected not found error but got %+v", err)
+	}
+}
@@ -18,6 +18,13 @@ const (
 	moduleVersion = "v0.1.0"
 )
 
+// RequestOption contains the extra headers and query parameters of the request. The `api-version` query parameter
+// is always set from the api-version argument, so it can't be overridden.
+type RequestOption struct {
+	Headers         map[string]string
+	QueryParameters map[string]string
+}
+
 type ResourceClient struct {
 	host           string
 	subscriptionID string
@@ -38,8 +45,8 @@ func NewResourceClient(subscriptionID string, credential azcore.TokenCredential,
 	}
 }
 
-func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}) (interface{}, *http.Response, error) {
-	resp, err := client.createOrUpdate(ctx, resourceID, apiVersion, body)
+func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}, option RequestOption) (interface{}, *http.Response, error) {
+	resp, err := client.createOrUpdate(ctx, resourceID, apiVersion, body, option)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -55,8 +62,8 @@ func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID str
 	return responseBody, resp, nil
 }
 
-func (client *ResourceClient) createOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}) (*http.Response, error) {
-	req, err := client.createOrUpdateCreateRequest(ctx, resourceID, apiVersion, body)
+func (client *ResourceClient) createOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}, option RequestOption) (*http.Response, error) {
+	req, err := client.createOrUpdateCreateRequest(ctx, resourceID, apiVersion, body, option)
 	if err != nil {
 		return nil, err
 	}
@@ -70,22 +77,19 @@ func (client *ResourceClient) createOrUpdate(ctx context.Context, resourceID str
 	return resp, nil
 }
 
-func (client *ResourceClient) createOrUpdateCreateRequest(ctx context.Context, resourceID string, apiVersion string, body interface{}) (*policy.Request, error) {
+func (client *ResourceClient) createOrUpdateCreateRequest(ctx context.Context, resourceID string, apiVersion string, body interface{}, option RequestOption) (*policy.Request, error) {
 	urlPath := "/{resourceId}"
 	urlPath = strings.ReplaceAll(urlPath, "{resourceId}", resourceID)
 	req, err := runtime.NewRequest(ctx, http.MethodPut, runtime.JoinPaths(client.host, urlPath))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", apiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
-	req.Raw().Header.Set("Accept", "application/json")
+	setRequestOption(req, apiVersion, option)
 	return req, runtime.MarshalAsJSON(req, body)
 }
 
-func (client *ResourceClient) Get(ctx context.Context, resourceID string, apiVersion string) (interface{}, *http.Response, error) {
-	req, err := client.getCreateRequest(ctx, resourceID, apiVersion)
+func (client *ResourceClient) Get(ctx context.Context, resourceID string, apiVersion string, option RequestOption) (interface{}, *http.Response, error) {
+	req, err := client.getCreateRequest(ctx, resourceID, apiVersion, option)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -104,22 +108,19 @@ func (client *ResourceClient) Get(ctx context.Context, resourceID string, apiVer
 	return responseBody, resp, nil
 }
 
-func (client *ResourceClient) getCreateRequest(ctx context.Context, resourceID string, apiVersion string) (*policy.Request, error) {
+func (client *ResourceClient) getCreateRequest(ctx context.Context, resourceID string, apiVersion string, option RequestOption) (*policy.Request, error) {
 	urlPath := "/{resourceId}"
 	urlPath = strings.ReplaceAll(urlPath, "{resourceId}", resourceID)
 	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.host, urlPath))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", apiVersion)
-	req.Raw().URL.RawQuery = reqQP.Encode()
-	req.Raw().Header.Set("Accept", "application/json")
+	setRequestOption(req, apiVersion, option)
 	return req, nil
 }
 
-func (client *ResourceClient) Delete(ctx context.Context, resourceID string, apiVersion string) (interface{}, *http.Response, error) {
-	resp, err := client.delete(ctx, resourceID, apiVersion)
+func (client *ResourceClient) Delete(ctx context.Context, resourceID string, apiVersion string, option RequestOption) (interface{}, *http.Response, error) {
+	resp, err := client.delete(ctx, resourceID, apiVersion, option)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -135,8 +136,8 @@ func (client *ResourceClient) Delete(ctx context.Context, resourceID string, api
 	return responseBody, resp, nil
 }
 
-func (client *ResourceClient) delete(ctx context.Context, resourceID string, apiVersion string) (*http.Response, error) {
-	req, err := client.deleteCreateRequest(ctx, resourceID, apiVersion)
+func (client *ResourceClient) delete(ctx context.Context, resourceID string, apiVersion string, option RequestOption) (*http.Response, error) {
+	req, err := client.deleteCreateRequest(ctx, resourceID, apiVersion, option)
 	if err != nil {
 		return nil, err
 	}
@@ -150,16 +151,27 @@ func (client *ResourceClient) delete(ctx context.Context, resourceID string, api
 	return resp, nil
 }
 
-func (client *ResourceClient) deleteCreateRequest(ctx context.Context, resourceID string, apiVersion string) (*policy.Request, error) {
+func (client *ResourceClient) deleteCreateRequest(ctx context.Context, resourceID string, apiVersion string, option RequestOption) (*policy.Request, error) {
 	urlPath := "/{resourceId}"
 	urlPath = strings.ReplaceAll(urlPath, "{resourceId}", resourceID)
 	req, err := runtime.NewRequest(ctx, http.MethodDelete, runtime.JoinPaths(client.host, urlPath))
 	if err != nil {
 		return nil, err
 	}
+	setRequestOption(req, apiVersion, option)
+	return req, nil
+}
+
+// setRequestOption sets the query parameters and the headers of the request, the api-version takes precedence over the query parameters.
+func setRequestOption(req *policy.Request, apiVersion string, option RequestOption) {
 	reqQP := req.Raw().URL.Query()
+	for key, value := range option.QueryParameters {
+		reqQP.Set(key, value)
+	}
 	reqQP.Set("api-version", apiVersion)
 	req.Raw().URL.RawQuery = reqQP.Encode()
 	req.Raw().Header.Set("Accept", "application/json")
-	return req, nil
+	for key, value := range option.Headers {
+		req.Raw().Header.Set(key, value)
+	}
 }
@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+func TestResourceClientRequestOption(t *testing.T) {
+	client := NewResourceClient("00000000-0000-0000-0000-000000000000", &fakeCredential{token: fakeToken(t, nil)}, nil)
+	resourceId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ContainerRegistry/registries/registry1"
+	option := RequestOption{
+		Headers: map[string]string{
+			"If-Match": "*",
+			"Accept":   "application/json;odata=nometadata",
+		},
+		QueryParameters: map[string]string{
+			"$expand":     "properties",
+			"api-version": "2000-01-01",
+		},
+	}
+
+	testData := []struct {
+		Method string
+		Create func() (*policy.Request, error)
+	}{
+		{
+			Method: http.MethodPut,
+			Create: func() (*policy.Request, error) {
+				return client.createOrUpdateCreateRequest(context.TODO(), resourceId, "2021-09-01", map[string]interface{}{}, option)
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Create: func() (*policy.Request, error) {
+				return client.getCreateRequest(context.TODO(), resourceId, "2021-09-01", option)
+			},
+		},
+		{
+			Method: http.MethodDelete,
+			Create: func() (*policy.Request, error) {
+				return client.deleteCreateRequest(context.TODO(), resourceId, "2021-09-01", option)
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Method)
+		req, err := v.Create()
+		if err != nil {
+			t.Fatalf("Expected no error but got %+v", err)
+		}
+		if req.Raw().Method != v.Method {
+			t.Fatalf("Expected method %s but got %s", v.Method, req.Raw().Method)
+		}
+		query := req.Raw().URL.Query()
+		// the api-version in the type takes precedence
+		if value := query.Get("api-version"); value != "2021-09-01" {
+			t.Fatalf("Expected api-version %s but got %s", "2021-09-01", value)
+		}
+		if value := query.Get("$expand"); value != "properties" {
+			t.Fatalf("Expected $expand %s but got %s", "properties", value)
+		}
+		if value := req.Raw().Header.Get("If-Match"); value != "*" {
+			t.Fatalf("Expected If-Match %s but got %s", "*", value)
+		}
+		if value := req.Raw().Header.Get("Accept"); value != "application/json;odata=nometadata" {
+			t.Fatalf("Expected Accept %s but got %s", "application/json;odata=nometadata", value)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+)
+
+const (
+	exitCodeOK       = 0
+	exitCodeFindings = 1
+	exitCodeError    = 2
+)
+
+type command struct {
+	synopsis string
+	run      func(args []string, stdout io.Writer, stderr io.Writer) int
+}
+
+func commands() map[string]command {
+	return map[string]command{
+		"schema": {
+			synopsis: "Explores the resource types, api-versions and properties in the embedded Azure schemas",
+			run:      runSchema,
+		},
+		"upgrade": {
+			synopsis: "Upgrades the api-versions of the azapi_resource blocks and reports the breaking changes",
+			run:      runUpgrade,
+		},
+		"validate": {
+			synopsis: "Validates request bodies against the embedded Azure schemas without calling Azure",
+			run:      runValidate,
+		},
+	}
+}
+
+// IsCommand returns whether the argument is a subcommand of the provider binary.
+func IsCommand(name string) bool {
+	_, ok := commands()[name]
+	return ok || name == "help"
+}
+
+// Run runs the subcommand and returns the exit code.
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" {
+		usage(stderr)
+		return exitCodeError
+	}
+	command, ok := commands()[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		usage(stderr)
+		return exitCodeError
+	}
+	if schemaPath := os.Getenv(azure.SchemaPathEnvName); schemaPath != "" {
+		if _, err := azure.LoadExternalSchema(schemaPath); err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+	}
+	return command.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: terraform-provider-azapi <command> [options]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range sortedCommandNames() {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands()[name].synopsis)
+	}
+	fmt.Fprintln(w, "")
+	fmt.Fprintf(w, "The schemas in the directory or the zip archive specified by the %s environment variable are overlaid on the embedded schemas.\n", azure.SchemaPathEnvName)
+}
+
+func sortedCommandNames() []string {
+	names := make([]string, 0)
+	for name := range commands() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
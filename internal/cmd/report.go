@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Azure/terraform-provider-azapi/version"
+	"github.com/hashicorp/hcl/v2"
+)
+
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+
+	ruleBodyValidation = "azapi-body-validation"
+)
+
+// Finding is an invalid property or an invalid `type` found by the validation.
+type Finding struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"end_line"`
+	EndColumn int    `json:"end_column"`
+	Address   string `json:"address,omitempty"`
+	Type      string `json:"type"`
+	Path      string `json:"path,omitempty"`
+	Message   string `json:"message"`
+}
+
+func newFinding(target bodyTarget, rng hcl.Range, path string, message string) Finding {
+	if rng.Filename == "" {
+		rng = target.BodyRange
+	}
+	return Finding{
+		File:      target.File,
+		Line:      rng.Start.Line,
+		Column:    rng.Start.Column,
+		EndLine:   rng.End.Line,
+		EndColumn: rng.End.Column,
+		Address:   target.Address,
+		Type:      target.Type,
+		Path:      path,
+		Message:   message,
+	}
+}
+
+func writeFindings(w io.Writer, format string, findings []Finding) error {
+	switch format {
+	case formatText:
+		for _, finding := range findings {
+			location := finding.Type
+			if finding.Address != "" {
+				location = finding.Address
+			}
+			fmt.Fprintf(w, "%s:%d:%d: %s: %s\n", finding.File, finding.Line, finding.Column, location, finding.Message)
+		}
+		return nil
+	case formatJSON:
+		return writeJSON(w, map[string]interface{}{
+			"version":  version.ProviderVersion,
+			"findings": findings,
+		})
+	case formatSARIF:
+		return writeJSON(w, sarifLog(findings))
+	}
+	return fmt.Errorf("unknown format %q, the supported formats are [%s, %s, %s]", format, formatText, formatJSON, formatSARIF)
+}
+
+func writeJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// sarifLog builds a SARIF 2.1.0 log which can be uploaded to the code scanning services.
+func sarifLog(findings []Finding) map[string]interface{} {
+	results := make([]interface{}, 0)
+	for _, finding := range findings {
+		results = append(results, map[string]interface{}{
+			"ruleId": ruleBodyValidation,
+			"level":  "error",
+			"message": map[string]interface{}{
+				"text": finding.Message,
+			},
+			"locations": []interface{}{
+				map[string]interface{}{
+					"physicalLocation": map[string]interface{}{
+						"artifactLocation": map[string]interface{}{
+							"uri": finding.File,
+						},
+						"region": map[string]interface{}{
+							"startLine":   finding.Line,
+							"startColumn": finding.Column,
+							"endLine":     finding.EndLine,
+							"endColumn":   finding.EndColumn,
+						},
+					},
+				},
+			},
+		})
+	}
+	return map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []interface{}{
+			map[string]interface{}{
+				"tool": map[string]interface{}{
+					"driver": map[string]interface{}{
+						"name":           "terraform-provider-azapi",
+						"version":        version.ProviderVersion,
+						"informationUri": "https://github.com/Azure/terraform-provider-azapi",
+						"rules": []interface{}{
+							map[string]interface{}{
+								"id": ruleBodyValidation,
+								"shortDescription": map[string]interface{}{
+									"text": "The request body doesn't match the Azure schema",
+								},
+							},
+						},
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+}
@@ -0,0 +1,423 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/tags"
+	"github.com/Azure/terraform-provider-azapi/internal/services"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// bodyTarget is a request body to validate, it's either a body file or the `body` of an `azapi_resource` block.
+type bodyTarget struct {
+	File      string
+	Address   string
+	Type      string
+	TypeRange hcl.Range
+	Body      interface{}
+	BodyRange hcl.Range
+	// BodyExpr is used to find the position of the invalid property, it's nil if the position can't be found.
+	BodyExpr hclsyntax.Expression
+	// Tags, Location and Identity are the literal top level arguments, they're merged into the body the same as the provider does.
+	Tags     map[string]interface{}
+	Location string
+	Identity []interface{}
+	// AttributeRanges are the ranges of the top level arguments, they're used to find the positions of the merged properties.
+	AttributeRanges map[string]hcl.Range
+	// Provider is the address of the provider configuration, like `azapi` or `azapi.<alias>`.
+	Provider        string
+	DefaultTags     map[string]string
+	DefaultLocation string
+}
+
+// requestBody returns the body and the top level arguments which are merged into it by the provider.
+func (target bodyTarget) requestBody() services.OfflineRequestBody {
+	return services.OfflineRequestBody{
+		Type:            target.Type,
+		Body:            target.Body,
+		Tags:            target.Tags,
+		Location:        target.Location,
+		Identity:        target.Identity,
+		DefaultTags:     target.DefaultTags,
+		DefaultLocation: target.DefaultLocation,
+	}
+}
+
+// locate returns the range of the property in the body, the properties merged from the top level arguments are located at the arguments.
+func (target bodyTarget) locate(path string) hcl.Range {
+	name, _, _ := strings.Cut(strings.TrimPrefix(path, "."), ".")
+	if rng, ok := target.AttributeRanges[name]; ok {
+		return rng
+	}
+	return locate(target.BodyExpr, path)
+}
+
+// providerDefaults are the literal `default_tags` and `default_location` of an `azapi` provider block.
+type providerDefaults struct {
+	Tags     map[string]string
+	Location string
+	// Unknown is true if the defaults aren't literals, the resources using the provider can't be validated offline.
+	Unknown bool
+}
+
+// loadBodyFile loads the json body file, the positions of the properties are found by parsing it as a HCL expression.
+func loadBodyFile(filename string, resourceType string) (*bodyTarget, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var body interface{}
+	if err := json.Unmarshal(src, &body); err != nil {
+		return nil, fmt.Errorf("parsing %s: %+v", filename, err)
+	}
+	target := &bodyTarget{
+		File:      filename,
+		Type:      resourceType,
+		TypeRange: hcl.Range{Filename: filename, Start: hcl.InitialPos, End: hcl.InitialPos},
+		Body:      body,
+		BodyRange: hcl.Range{Filename: filename, Start: hcl.InitialPos, End: hcl.InitialPos},
+	}
+	if expr, diags := hclsyntax.ParseExpression(src, filename, hcl.InitialPos); !diags.HasErrors() {
+		target.BodyExpr = expr
+		target.BodyRange = expr.Range()
+	}
+	return target, nil
+}
+
+// scanPaths finds the `azapi_resource` blocks whose `type` and `body` are literals in the .tf files. The defaults of the `azapi`
+// provider blocks are applied to the resources in the same directory.
+func scanPaths(paths []string) ([]bodyTarget, hcl.Diagnostics) {
+	scanned := make([]bodyTarget, 0)
+	providers := make(map[string]map[string]providerDefaults)
+	var diags hcl.Diagnostics
+	for _, path := range paths {
+		err := filepath.Walk(path, func(filename string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if filename != path && (strings.HasPrefix(info.Name(), ".") || info.Name() == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(filename) != ".tf" {
+				return nil
+			}
+			fileTargets, fileProviders, fileDiags := scanFile(filename)
+			scanned = append(scanned, fileTargets...)
+			diags = append(diags, fileDiags...)
+			dir := filepath.Dir(filename)
+			if providers[dir] == nil {
+				providers[dir] = make(map[string]providerDefaults)
+			}
+			for address, defaults := range fileProviders {
+				providers[dir][address] = defaults
+			}
+			return nil
+		})
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("reading %s: %+v", path, err),
+			})
+		}
+	}
+
+	targets := make([]bodyTarget, 0, len(scanned))
+	for _, target := range scanned {
+		defaults := providers[filepath.Dir(target.File)][target.Provider]
+		if defaults.Unknown {
+			continue
+		}
+		target.DefaultTags = defaults.Tags
+		target.DefaultLocation = defaults.Location
+		targets = append(targets, target)
+	}
+	return targets, diags
+}
+
+// scanFile returns the body targets and the defaults of the `azapi` provider blocks in the file, the defaults are keyed by the provider's address.
+func scanFile(filename string) ([]bodyTarget, map[string]providerDefaults, hcl.Diagnostics) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("reading %s: %+v", filename, err),
+		}}
+	}
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	targets := make([]bodyTarget, 0)
+	providers := make(map[string]providerDefaults)
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		switch {
+		case block.Type == "provider" && len(block.Labels) == 1 && block.Labels[0] == "azapi":
+			address, defaults := scanProviderBlock(block)
+			providers[address] = defaults
+		case block.Type == "resource" && len(block.Labels) == 2 && block.Labels[0] == "azapi_resource":
+			if target := scanResourceBlock(filename, block); target != nil {
+				targets = append(targets, *target)
+			}
+		}
+	}
+	return targets, providers, nil
+}
+
+// scanProviderBlock returns the address and the defaults of the `azapi` provider block.
+func scanProviderBlock(block *hclsyntax.Block) (string, providerDefaults) {
+	attributes := block.Body.Attributes
+	address := "azapi"
+	if attr, ok := attributes["alias"]; ok {
+		if alias, ok := literalString(attr.Expr); ok {
+			address = "azapi." + alias
+		}
+	}
+
+	defaults := providerDefaults{}
+	if attr, ok := attributes["default_tags"]; ok {
+		value, ok := literalValue(attr.Expr)
+		tagsMap, isMap := value.(map[string]interface{})
+		if !ok || !isMap {
+			return address, providerDefaults{Unknown: true}
+		}
+		defaults.Tags = tags.ExpandTags(tagsMap)
+	}
+	if attr, ok := attributes["default_location"]; ok {
+		value, ok := literalString(attr.Expr)
+		if !ok {
+			return address, providerDefaults{Unknown: true}
+		}
+		defaults.Location = value
+	}
+	return address, defaults
+}
+
+// scanResourceBlock returns the body target of the `azapi_resource` block, it returns nil if the block can't be validated offline.
+func scanResourceBlock(filename string, block *hclsyntax.Block) *bodyTarget {
+	attributes := block.Body.Attributes
+	if attr, ok := attributes["schema_validation_enabled"]; ok {
+		if value, diags := attr.Expr.Value(nil); !diags.HasErrors() && value.IsKnown() && !value.IsNull() && value.False() {
+			return nil
+		}
+	}
+
+	typeAttr, ok := attributes["type"]
+	if !ok {
+		return nil
+	}
+	resourceType, ok := literalString(typeAttr.Expr)
+	if !ok {
+		return nil
+	}
+
+	target := &bodyTarget{
+		File:            filename,
+		Address:         fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1]),
+		Type:            resourceType,
+		TypeRange:       typeAttr.Expr.Range(),
+		Body:            map[string]interface{}{},
+		BodyRange:       block.DefRange(),
+		AttributeRanges: make(map[string]hcl.Range),
+		Provider:        "azapi",
+	}
+	if attr, ok := attributes["provider"]; ok {
+		traversal, diags := hcl.AbsTraversalForExpr(attr.Expr)
+		if diags.HasErrors() {
+			return nil
+		}
+		target.Provider = strings.Join(traversalNames(traversal), ".")
+	}
+
+	if bodyAttr, ok := attributes["body"]; ok {
+		body, bodyExpr, ok := literalBody(bodyAttr.Expr)
+		if !ok {
+			return nil
+		}
+		target.Body = body
+		target.BodyExpr = bodyExpr
+		target.BodyRange = bodyAttr.Expr.Range()
+	}
+
+	// the top level arguments are merged into the body when it's validated, the same as the provider does in plan
+	if attr, ok := attributes["tags"]; ok {
+		value, ok := literalValue(attr.Expr)
+		tagsMap, isMap := value.(map[string]interface{})
+		if !ok || !isMap {
+			return nil
+		}
+		target.Tags = tagsMap
+		target.AttributeRanges["tags"] = attr.Expr.Range()
+	}
+	if attr, ok := attributes["location"]; ok {
+		value, ok := literalString(attr.Expr)
+		if !ok {
+			return nil
+		}
+		target.Location = value
+		target.AttributeRanges["location"] = attr.Expr.Range()
+	}
+	for _, nested := range block.Body.Blocks {
+		if nested.Type != "identity" {
+			continue
+		}
+		identity, ok := literalIdentity(nested)
+		if !ok {
+			return nil
+		}
+		target.Identity = []interface{}{identity}
+		target.AttributeRanges["identity"] = nested.DefRange()
+	}
+	return target
+}
+
+// literalIdentity returns the `identity` block in the same format as the provider's schema, it returns false if it's not a literal.
+func literalIdentity(block *hclsyntax.Block) (map[string]interface{}, bool) {
+	identity := map[string]interface{}{
+		"type":         "",
+		"identity_ids": []interface{}{},
+	}
+	if attr, ok := block.Body.Attributes["type"]; ok {
+		value, ok := literalString(attr.Expr)
+		if !ok {
+			return nil, false
+		}
+		identity["type"] = value
+	}
+	if attr, ok := block.Body.Attributes["identity_ids"]; ok {
+		value, ok := literalValue(attr.Expr)
+		ids, isList := value.([]interface{})
+		if !ok || !isList {
+			return nil, false
+		}
+		for _, id := range ids {
+			if _, ok := id.(string); !ok {
+				return nil, false
+			}
+		}
+		identity["identity_ids"] = ids
+	}
+	return identity, true
+}
+
+func traversalNames(traversal hcl.Traversal) []string {
+	names := make([]string, 0, len(traversal))
+	for _, step := range traversal {
+		switch v := step.(type) {
+		case hcl.TraverseRoot:
+			names = append(names, v.Name)
+		case hcl.TraverseAttr:
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
+
+// literalBody returns the value of `jsonencode(...)` or a json string, and the expression which is used to find the positions.
+func literalBody(expr hclsyntax.Expression) (interface{}, hclsyntax.Expression, bool) {
+	switch v := expr.(type) {
+	case *hclsyntax.FunctionCallExpr:
+		if v.Name != "jsonencode" || len(v.Args) != 1 {
+			return nil, nil, false
+		}
+		value, ok := literalValue(v.Args[0])
+		return value, v.Args[0], ok
+	case *hclsyntax.TemplateExpr:
+		input, ok := literalString(v)
+		if !ok || len(v.Parts) == 0 {
+			return nil, nil, false
+		}
+		var body interface{}
+		if err := json.Unmarshal([]byte(input), &body); err != nil {
+			return nil, nil, false
+		}
+		// parse the json string again to find the positions of the properties
+		bodyExpr, diags := hclsyntax.ParseExpression([]byte(input), v.Range().Filename, v.Parts[0].Range().Start)
+		if diags.HasErrors() {
+			bodyExpr = nil
+		}
+		return body, bodyExpr, true
+	}
+	return nil, nil, false
+}
+
+func literalString(expr hclsyntax.Expression) (string, bool) {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || value.Type().FriendlyName() != "string" {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
+func literalValue(expr hclsyntax.Expression) (interface{}, bool) {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() {
+		return nil, false
+	}
+	data, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return nil, false
+	}
+	var output interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, false
+	}
+	return output, true
+}
+
+// locate returns the range of the property in the expression, the path is in a format like `properties.subnets.0.name`.
+// If the property doesn't exist, the range of its closest existing parent is returned.
+func locate(expr hclsyntax.Expression, path string) hcl.Range {
+	if expr == nil {
+		return hcl.Range{}
+	}
+	current := expr
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		if segment == "" {
+			continue
+		}
+		next := locateChild(current, segment)
+		if next == nil {
+			break
+		}
+		current = next
+	}
+	return current.Range()
+}
+
+func locateChild(expr hclsyntax.Expression, segment string) hclsyntax.Expression {
+	switch v := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range v.Items {
+			if key, ok := objectKey(item.KeyExpr); ok && key == segment {
+				return item.ValueExpr
+			}
+		}
+	case *hclsyntax.TupleConsExpr:
+		for index, item := range v.Exprs {
+			if fmt.Sprintf("%d", index) == segment {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+func objectKey(expr hclsyntax.Expression) (string, bool) {
+	if keyExpr, ok := expr.(*hclsyntax.ObjectConsKeyExpr); ok {
+		if keyword := hcl.ExprAsKeyword(keyExpr.Wrapped); keyword != "" {
+			return keyword, true
+		}
+		return literalString(keyExpr.Wrapped)
+	}
+	return literalString(expr)
+}
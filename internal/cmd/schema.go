@@ -0,0 +1,484 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/jsonschema"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+const (
+	formatJSONSchema = "json-schema"
+	indentUnit       = "  "
+)
+
+func runSchema(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		schemaUsage(stderr)
+		return exitCodeError
+	}
+	switch args[0] {
+	case "list-types":
+		return runSchemaListTypes(args[1:], stdout, stderr)
+	case "list-versions":
+		return runSchemaListVersions(args[1:], stdout, stderr)
+	case "show":
+		return runSchemaShow(args[1:], stdout, stderr)
+	case "export":
+		return runSchemaExport(args[1:], stdout, stderr)
+	}
+	fmt.Fprintf(stderr, "unknown schema command %q\n\n", args[0])
+	schemaUsage(stderr)
+	return exitCodeError
+}
+
+func schemaUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: terraform-provider-azapi schema <command> [options]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintf(w, "  %-40s %s\n", "list-types", "Lists the resource types in the embedded schemas")
+	fmt.Fprintf(w, "  %-40s %s\n", "list-versions <resource-type>", "Lists the api-versions of the resource type")
+	fmt.Fprintf(w, "  %-40s %s\n", "show [-format] <resource-type@api-version> [path]", "Shows the properties of the resource type")
+	fmt.Fprintf(w, "  %-40s %s\n", "export -out <dir> <resource-type@api-version>...", "Exports the JSON Schema documents of the resource types' bodies")
+}
+
+func runSchemaListTypes(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) != 0 {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-azapi schema list-types")
+		return exitCodeError
+	}
+	if azure.GetAzureSchema() == nil {
+		fmt.Fprintln(stderr, "failed to load azure schema index")
+		return exitCodeError
+	}
+	resourceTypes := azure.GetResourceTypes()
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		return strings.ToLower(resourceTypes[i]) < strings.ToLower(resourceTypes[j])
+	})
+	for _, resourceType := range resourceTypes {
+		fmt.Fprintln(stdout, resourceType)
+	}
+	return exitCodeOK
+}
+
+func runSchemaListVersions(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-azapi schema list-versions <resource-type>")
+		return exitCodeError
+	}
+	versions := azure.GetApiVersions(args[0])
+	if len(versions) == 0 {
+		fmt.Fprintf(stderr, "resource type %s can't be found\n", args[0])
+		if suggestions := azure.SuggestResourceTypes(args[0]); len(suggestions) != 0 {
+			fmt.Fprintf(stderr, "did you mean: %s\n", strings.Join(suggestions, ", "))
+		}
+		return exitCodeError
+	}
+	for _, version := range versions {
+		if source := azure.GetResourceDefinitionSource(args[0], version); source != "" {
+			fmt.Fprintf(stdout, "%s (%s)\n", version, source)
+			continue
+		}
+		fmt.Fprintln(stdout, version)
+	}
+	return exitCodeOK
+}
+
+func runSchemaShow(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("schema show", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", formatText, "The output format, possible values are `text` and `json-schema`.")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-azapi schema show [options] <resource-type@api-version> [path]")
+		fmt.Fprintln(stderr, "")
+		fmt.Fprintln(stderr, "  Shows the properties of the resource type's body, the path like `properties.sku` selects a nested property.")
+		fmt.Fprintln(stderr, "")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *format != formatText && *format != formatJSONSchema {
+		fmt.Fprintf(stderr, "unknown format %q, the supported formats are [%s, %s]\n", *format, formatText, formatJSONSchema)
+		return exitCodeError
+	}
+	if flags.NArg() != 1 && flags.NArg() != 2 {
+		flags.Usage()
+		return exitCodeError
+	}
+
+	resourceDef, err := loadResourceDefinition(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return exitCodeError
+	}
+
+	title := flags.Arg(0)
+	var target types.TypeBase = resourceDef
+	if path := flags.Arg(1); path != "" {
+		target, err = findType(target, path)
+		if err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+		title = fmt.Sprintf("%s %s", title, path)
+	}
+
+	switch *format {
+	case formatJSONSchema:
+		if err := writeJSONSchema(stdout, title, target); err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+	default:
+		fmt.Fprintln(stdout, title)
+		printer := treePrinter{
+			w:        stdout,
+			visiting: make(map[types.TypeBase]bool),
+		}
+		printer.printChildren(target, indentUnit)
+	}
+	return exitCodeOK
+}
+
+func runSchemaExport(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("schema export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	out := flags.String("out", ".", "The directory to write the JSON Schema documents to.")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-azapi schema export [options] <resource-type@api-version>...")
+		fmt.Fprintln(stderr, "")
+		fmt.Fprintln(stderr, "  Exports the JSON Schema documents of the resource types' bodies, the documents are named like `Microsoft.ContainerRegistry_registries@2019-05-01.schema.json`.")
+		fmt.Fprintln(stderr, "")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitCodeError
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return exitCodeError
+	}
+
+	for _, resourceType := range flags.Args() {
+		resourceDef, err := loadResourceDefinition(resourceType)
+		if err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+		filename := filepath.Join(*out, strings.ReplaceAll(resourceType, "/", "_")+".schema.json")
+		file, err := os.Create(filename)
+		if err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+		err = writeJSONSchema(file, resourceType, resourceDef)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+		fmt.Fprintln(stdout, filename)
+	}
+	return exitCodeOK
+}
+
+// loadResourceDefinition returns the definition of the resource type in a format like `<resource-type>@<api-version>`.
+func loadResourceDefinition(input string) (*types.ResourceType, error) {
+	resourceType, apiVersion, ok := utils.SplitResourceType(input)
+	if !ok {
+		return nil, fmt.Errorf("the resource type %s is invalid, expect `<resource-type>@<api-version>`", input)
+	}
+	if err := azure.ValidateResourceType(resourceType, apiVersion); err != nil {
+		return nil, errors.New(strings.TrimSpace(err.Error()))
+	}
+	resourceDef, err := azure.GetResourceDefinition(resourceType, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	if resourceDef == nil || resourceDef.Body == nil || resourceDef.Body.Type == nil {
+		return nil, fmt.Errorf("the body of %s is not defined", input)
+	}
+	return resourceDef, nil
+}
+
+func writeJSONSchema(w io.Writer, title string, t types.TypeBase) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonschema.Generate(title, t))
+}
+
+// findType returns the type of the property selected by the dot-separated path, the property names are case-insensitive.
+// The items of the arrays are selected implicitly, and the discriminator values could be used to select the elements of the discriminated objects.
+func findType(t types.TypeBase, path string) (types.TypeBase, error) {
+	current := t
+	for index, segment := range strings.Split(path, ".") {
+		current = unwrapArray(current)
+		next := childType(current, segment)
+		if next == nil {
+			return nil, fmt.Errorf("property %s can't be found", strings.Join(strings.Split(path, ".")[0:index+1], "."))
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func childType(t types.TypeBase, name string) types.TypeBase {
+	switch v := t.(type) {
+	case *types.ResourceType:
+		return childType(referenceType(v.Body), name)
+	case *types.ObjectType:
+		if property, ok := findProperty(v.Properties, name); ok {
+			return referenceType(property.Type)
+		}
+		if v.AdditionalProperties != nil {
+			return referenceType(v.AdditionalProperties)
+		}
+	case *types.DiscriminatedObjectType:
+		if property, ok := findProperty(v.BaseProperties, name); ok {
+			return referenceType(property.Type)
+		}
+		for _, key := range sortedElementKeys(v.Elements) {
+			if strings.EqualFold(key, name) {
+				return referenceType(v.Elements[key])
+			}
+		}
+		for _, key := range sortedElementKeys(v.Elements) {
+			if child := childType(referenceType(v.Elements[key]), name); child != nil {
+				return child
+			}
+		}
+	}
+	return nil
+}
+
+func findProperty(properties map[string]types.ObjectProperty, name string) (types.ObjectProperty, bool) {
+	if property, ok := properties[name]; ok {
+		return property, true
+	}
+	for key, property := range properties {
+		if strings.EqualFold(key, name) {
+			return property, true
+		}
+	}
+	return types.ObjectProperty{}, false
+}
+
+func unwrapArray(t types.TypeBase) types.TypeBase {
+	for {
+		arrayType, ok := t.(*types.ArrayType)
+		if !ok {
+			return t
+		}
+		t = referenceType(arrayType.ItemType)
+	}
+}
+
+func referenceType(reference *types.TypeReference) types.TypeBase {
+	if reference == nil || reference.Type == nil {
+		return nil
+	}
+	return *reference.Type
+}
+
+// treePrinter prints the properties of a type as an indented tree, each line contains the property name, the type, the flags and the description.
+type treePrinter struct {
+	w        io.Writer
+	visiting map[types.TypeBase]bool
+}
+
+func (p *treePrinter) printChildren(t types.TypeBase, indent string) {
+	t = unwrapArray(t)
+	if t == nil {
+		return
+	}
+	// recursive types are only expanded once in a branch
+	if p.visiting[t] {
+		fmt.Fprintf(p.w, "%s(recursive)\n", indent)
+		return
+	}
+	p.visiting[t] = true
+	defer delete(p.visiting, t)
+
+	switch v := t.(type) {
+	case *types.ResourceType:
+		p.printChildren(referenceType(v.Body), indent)
+	case *types.ObjectType:
+		p.printProperties(v.Properties, indent)
+		// the values of the maps are only expanded when they're objects
+		if additional := referenceType(v.AdditionalProperties); hasProperties(additional) {
+			fmt.Fprintf(p.w, "%s<key>: %s\n", indent, typeLabel(additional))
+			p.printChildren(additional, indent+indentUnit)
+		}
+	case *types.DiscriminatedObjectType:
+		p.printProperties(v.BaseProperties, indent)
+		for _, key := range sortedElementKeys(v.Elements) {
+			fmt.Fprintf(p.w, "%s%s = %q:\n", indent, v.Discriminator, key)
+			p.printChildren(referenceType(v.Elements[key]), indent+indentUnit)
+		}
+	case *types.UnionType:
+		if isStringLiteralUnion(v) {
+			return
+		}
+		for _, element := range v.Elements {
+			p.printChildren(referenceType(element), indent)
+		}
+	}
+}
+
+func (p *treePrinter) printProperties(properties map[string]types.ObjectProperty, indent string) {
+	names := make([]string, 0)
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := properties[name]
+		line := fmt.Sprintf("%s%s: %s", indent, name, typeLabel(referenceType(property.Type)))
+		flags := append(propertyFlags(property), typeConstraints(referenceType(property.Type))...)
+		if len(flags) != 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(flags, ", "))
+		}
+		if property.Description != nil && *property.Description != "" {
+			line += " - " + strings.Join(strings.Fields(*property.Description), " ")
+		}
+		fmt.Fprintln(p.w, line)
+		p.printChildren(referenceType(property.Type), indent+indentUnit)
+	}
+}
+
+func propertyFlags(property types.ObjectProperty) []string {
+	flags := make([]string, 0)
+	if property.IsRequired() {
+		flags = append(flags, "required")
+	}
+	if property.IsReadOnly() {
+		flags = append(flags, "readonly")
+	}
+	if property.IsWriteOnly() {
+		flags = append(flags, "writeonly")
+	}
+	return flags
+}
+
+// typeConstraints returns the constraints of the type, like `length 3..50` or `pattern ^[a-z]+$`.
+func typeConstraints(t types.TypeBase) []string {
+	constraints := make([]string, 0)
+	switch v := t.(type) {
+	case *types.StringType:
+		if v.MinLength != nil || v.MaxLength != nil {
+			constraints = append(constraints, "length "+rangeLabel(v.MinLength, v.MaxLength))
+		}
+		if v.Pattern != "" {
+			constraints = append(constraints, "pattern "+v.Pattern)
+		}
+	case *types.IntegerType:
+		if v.MinValue != nil || v.MaxValue != nil {
+			var minValue, maxValue *int
+			if v.MinValue != nil {
+				value := int(*v.MinValue)
+				minValue = &value
+			}
+			if v.MaxValue != nil {
+				value := int(*v.MaxValue)
+				maxValue = &value
+			}
+			constraints = append(constraints, "value "+rangeLabel(minValue, maxValue))
+		}
+	case *types.ArrayType:
+		if v.MinLength != nil || v.MaxLength != nil {
+			constraints = append(constraints, "items "+rangeLabel(v.MinLength, v.MaxLength))
+		}
+	}
+	return constraints
+}
+
+func rangeLabel(min *int, max *int) string {
+	label := ""
+	if min != nil {
+		label += fmt.Sprint(*min)
+	}
+	label += ".."
+	if max != nil {
+		label += fmt.Sprint(*max)
+	}
+	return label
+}
+
+// typeLabel returns a short description of the type, like `string`, `array of object` or `"Basic" | "Premium"`.
+func typeLabel(t types.TypeBase) string {
+	switch v := t.(type) {
+	case nil:
+		return "any"
+	case *types.BuiltInType:
+		return v.Kind.String()
+	case *types.StringLiteralType:
+		return fmt.Sprintf("%q", v.Value)
+	case *types.StringType:
+		return "string"
+	case *types.IntegerType:
+		return "int"
+	case *types.ArrayType:
+		return "array of " + typeLabel(referenceType(v.ItemType))
+	case *types.ObjectType:
+		if len(v.Properties) == 0 && v.AdditionalProperties != nil {
+			return "map of " + typeLabel(referenceType(v.AdditionalProperties))
+		}
+		return "object"
+	case *types.DiscriminatedObjectType:
+		return fmt.Sprintf("object, discriminated by %s", v.Discriminator)
+	case *types.UnionType:
+		labels := make([]string, 0)
+		for _, element := range v.Elements {
+			labels = append(labels, typeLabel(referenceType(element)))
+		}
+		return strings.Join(labels, " | ")
+	case *types.ResourceType:
+		return typeLabel(referenceType(v.Body))
+	}
+	return "any"
+}
+
+func hasProperties(t types.TypeBase) bool {
+	switch v := unwrapArray(t).(type) {
+	case *types.ObjectType:
+		return len(v.Properties) != 0 || hasProperties(referenceType(v.AdditionalProperties))
+	case *types.DiscriminatedObjectType:
+		return true
+	}
+	return false
+}
+
+func isStringLiteralUnion(t *types.UnionType) bool {
+	for _, element := range t.Elements {
+		if _, ok := referenceType(element).(*types.StringLiteralType); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedElementKeys(elements map[string]*types.TypeReference) []string {
+	keys := make([]string, 0)
+	for key := range elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_SchemaListVersions(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := Run([]string{"schema", "list-versions", "microsoft.containerregistry/registries"}, stdout, stderr)
+	if code != exitCodeOK {
+		t.Fatalf("Expected exit code %d but got %d: %s", exitCodeOK, code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "2019-05-01\n") {
+		t.Fatalf("Expected api-version %q in %q", "2019-05-01", stdout.String())
+	}
+
+	code = Run([]string{"schema", "list-versions", "Microsoft.Unknown/unknown"}, stdout, stderr)
+	if code != exitCodeError {
+		t.Fatalf("Expected exit code %d but got %d", exitCodeError, code)
+	}
+}
+
+func Test_SchemaShow(t *testing.T) {
+	testData := []struct {
+		Args     []string
+		Code     int
+		Expected []string
+	}{
+		{
+			Args: []string{"Microsoft.ContainerRegistry/registries@2019-05-01"},
+			Code: exitCodeOK,
+			Expected: []string{
+				"  location: string (required) - The location of the resource.",
+				"  id: string (readonly) - The resource id",
+				"    adminUserEnabled: bool - The value that indicates whether the admin user is enabled.",
+				`    name: "Classic" | "Basic" | "Standard" | "Premium" (required) - The SKU name of the container registry.`,
+				"  tags: map of string - The tags of the resource.",
+			},
+		},
+		{
+			Args: []string{"Microsoft.ContainerRegistry/registries@2019-05-01", "Properties.NetworkRuleSet.ipRules"},
+			Code: exitCodeOK,
+			Expected: []string{
+				"Microsoft.ContainerRegistry/registries@2019-05-01 Properties.NetworkRuleSet.ipRules\n",
+				"\n  value: string (required) - Specifies the IP or IP range in CIDR format.",
+			},
+		},
+		{
+			Args: []string{"Microsoft.Web/sites/config@2021-03-01", "logs.properties.applicationLogs"},
+			Code: exitCodeOK,
+			Expected: []string{
+				"\n  azureBlobStorage: object - Application logs azure blob storage configuration.",
+			},
+		},
+		{
+			Args: []string{"Microsoft.Web/sites/config@2021-03-01"},
+			Code: exitCodeOK,
+			Expected: []string{
+				"\n  name = \"logs\":\n",
+			},
+		},
+		{
+			Args: []string{"Microsoft.ContainerRegistry/registries@2019-05-01", "properties.unknown"},
+			Code: exitCodeError,
+		},
+		{
+			Args: []string{"Microsoft.ContainerRegistry/registries@1990-01-01"},
+			Code: exitCodeError,
+		},
+		{
+			Args: []string{"Microsoft.ContainerRegistry/registries"},
+			Code: exitCodeError,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %v", v.Args)
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := Run(append([]string{"schema", "show"}, v.Args...), stdout, stderr)
+		if code != v.Code {
+			t.Fatalf("Expected exit code %d but got %d: %s", v.Code, code, stderr.String())
+		}
+		for _, expected := range v.Expected {
+			if !strings.Contains(stdout.String(), expected) {
+				t.Fatalf("Expected %q in %q", expected, stdout.String())
+			}
+		}
+	}
+}
+
+func Test_SchemaShowJSONSchema(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := Run([]string{"schema", "show", "-format", "json-schema", "Microsoft.ContainerRegistry/registries@2019-05-01"}, stdout, stderr)
+	if code != exitCodeOK {
+		t.Fatalf("Expected exit code %d but got %d: %s", exitCodeOK, code, stderr.String())
+	}
+
+	var document struct {
+		Schema string `json:"$schema"`
+		Title  string `json:"title"`
+		Ref    string `json:"$ref"`
+		Defs   map[string]struct {
+			Required   []string `json:"required"`
+			Properties map[string]struct {
+				Ref      string   `json:"$ref"`
+				Enum     []string `json:"enum"`
+				ReadOnly bool     `json:"readOnly"`
+			} `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &document); err != nil {
+		t.Fatal(err)
+	}
+	if document.Schema != "https://json-schema.org/draft/2020-12/schema" {
+		t.Fatalf("Expected $schema %q but got %q", "https://json-schema.org/draft/2020-12/schema", document.Schema)
+	}
+	if document.Title != "Microsoft.ContainerRegistry/registries@2019-05-01" {
+		t.Fatalf("Expected title %q but got %q", "Microsoft.ContainerRegistry/registries@2019-05-01", document.Title)
+	}
+	body := document.Defs[strings.TrimPrefix(document.Ref, "#/$defs/")]
+	if strings.Join(body.Required, ",") != "location,sku" {
+		t.Fatalf("Expected required %q but got %q", "location,sku", strings.Join(body.Required, ","))
+	}
+	if !body.Properties["id"].ReadOnly {
+		t.Fatalf("Expected %q to be read-only", "id")
+	}
+	sku := document.Defs[strings.TrimPrefix(body.Properties["sku"].Ref, "#/$defs/")]
+	if enum := strings.Join(sku.Properties["name"].Enum, ","); enum != "Classic,Basic,Standard,Premium" {
+		t.Fatalf("Expected enum %q but got %q", "Classic,Basic,Standard,Premium", enum)
+	}
+}
+
+func Test_SchemaExport(t *testing.T) {
+	dir := t.TempDir()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := Run([]string{"schema", "export", "-out", dir, "Microsoft.ContainerRegistry/registries@2019-05-01", "Microsoft.Web/sites/config@2021-03-01"}, stdout, stderr)
+	if code != exitCodeOK {
+		t.Fatalf("Expected exit code %d but got %d: %s", exitCodeOK, code, stderr.String())
+	}
+	for _, filename := range []string{"Microsoft.ContainerRegistry_registries@2019-05-01.schema.json", "Microsoft.Web_sites_config@2021-03-01.schema.json"} {
+		t.Logf("[DEBUG] Testing Value %s", filename)
+		data, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var document map[string]interface{}
+		if err := json.Unmarshal(data, &document); err != nil {
+			t.Fatal(err)
+		}
+		if document["$ref"] == nil || document["$defs"] == nil {
+			t.Fatalf("Expected $ref and $defs in %s", filename)
+		}
+	}
+
+	code = Run([]string{"schema", "export", "-out", dir, "Microsoft.ContainerRegistry/registries@1990-01-01"}, stdout, stderr)
+	if code != exitCodeError {
+		t.Fatalf("Expected exit code %d but got %d", exitCodeError, code)
+	}
+}
@@ -0,0 +1,41 @@
+resource "azapi_resource" "registry" {
+  name      = "registry1"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1"
+  type      = "Microsoft.ContainerRegistry/registries@2019-05-01"
+  location  = "westeurope"
+  body = jsonencode({
+    sku = {
+      name = "Premium"
+    }
+    properties = {
+      adminUserEnabled = true
+      storageAccount = {
+        id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/account1"
+      }
+      networkRuleSet = {
+        defaultAction       = "Deny"
+        virtualNetworkRules = []
+      }
+    }
+  })
+}
+
+resource "azapi_resource" "dynamic" {
+  name      = "registry2"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1"
+  type      = "Microsoft.ContainerRegistry/registries@2019-05-01"
+  location  = "westeurope"
+  body      = jsonencode(var.registry_body)
+}
+
+resource "azapi_resource" "namespace" {
+  name      = "namespace1"
+  parent_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1"
+  type      = "Microsoft.ServiceBus/namespaces@2021-06-01-preview"
+  location  = "westeurope"
+  body = jsonencode({
+    sku = {
+      name = "Standard"
+    }
+  })
+}
@@ -0,0 +1,39 @@
+resource "azapi_resource" "test" {
+  type      = "Microsoft.ContainerRegistry/registries@2020-11-01-preview"
+  name      = "acctest"
+  parent_id = azurerm_resource_group.test.id
+  location  = "westus"
+  body = jsonencode({
+    sku = {
+      name = "Premiumm"
+    }
+    properties = {
+      adminUserEnabled = "true"
+    }
+  })
+}
+
+resource "azapi_resource" "heredoc" {
+  type      = "Microsoft.ContainerRegistry/registries@2020-11-01-preview"
+  name      = "acctest"
+  parent_id = azurerm_resource_group.test.id
+  body = <<BODY
+{
+  "location": "westus",
+  "sku": {
+    "name": "Premium"
+  },
+  "properties": {
+    "unknownProp": true
+  }
+}
+BODY
+}
+
+resource "azapi_resource" "reference" {
+  type = "Microsoft.ContainerRegistry/registries@2020-11-01-preview"
+  name = "acctest"
+  body = jsonencode({
+    sku = var.sku
+  })
+}
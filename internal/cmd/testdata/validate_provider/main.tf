@@ -0,0 +1,54 @@
+provider "azapi" {
+  default_location = "westus"
+  default_tags = {
+    env = "test"
+  }
+}
+
+provider "azapi" {
+  alias        = "unknown"
+  default_tags = var.tags
+}
+
+resource "azapi_resource" "identity" {
+  type      = "Microsoft.ContainerRegistry/registries@2020-11-01-preview"
+  name      = "acctest"
+  parent_id = azurerm_resource_group.test.id
+  identity {
+    type = "UserAssigned"
+  }
+  body = jsonencode({
+    sku = {
+      name = "Premium"
+    }
+  })
+}
+
+resource "azapi_resource" "duplicated" {
+  type      = "Microsoft.ContainerRegistry/registries@2020-11-01-preview"
+  name      = "acctest"
+  parent_id = azurerm_resource_group.test.id
+  tags = {
+    env = "test"
+  }
+  body = jsonencode({
+    sku = {
+      name = "Premium"
+    }
+    tags = {
+      env = "test"
+    }
+  })
+}
+
+resource "azapi_resource" "aliased" {
+  provider  = azapi.unknown
+  type      = "Microsoft.ContainerRegistry/registries@2020-11-01-preview"
+  name      = "acctest"
+  parent_id = azurerm_resource_group.test.id
+  body = jsonencode({
+    sku = {
+      name = "Premiumm"
+    }
+  })
+}
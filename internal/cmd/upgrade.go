@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	azureutils "github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+	"github.com/Azure/terraform-provider-azapi/internal/services"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// upgradeTarget is an `azapi_resource` block whose `type` is upgraded to the target api-version.
+type upgradeTarget struct {
+	Address string
+	// Type is the upgraded type, ApiVersion is the current api-version
+	Type       string
+	TypeRange  hcl.Range
+	ApiVersion string
+	// Body is nil if the body can't be validated offline
+	Body *bodyTarget
+}
+
+func runUpgrade(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("upgrade", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	apiVersion := flags.String("api-version", "", "The api-version to upgrade to.")
+	resourceType := flags.String("type", "", "The resource type to upgrade, all the resource types which have the api-version are upgraded if it's not specified.")
+	dryRun := flags.Bool("dry-run", false, "Reports the changes without rewriting the files.")
+	format := flags.String("format", formatText, "The output format, possible values are `text`, `json` and `sarif`.")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-azapi upgrade [options] <files...>")
+		fmt.Fprintln(stderr, "")
+		fmt.Fprintln(stderr, "  Upgrades the `type` of the azapi_resource blocks in the .tf files to the api-version. The literal bodies are validated against the api-version,")
+		fmt.Fprintln(stderr, "  and the properties which are removed or renamed in the api-version are reported. The api-version is updated in place when it's applied.")
+		fmt.Fprintln(stderr, "")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *apiVersion == "" || flags.NArg() == 0 {
+		flags.Usage()
+		return exitCodeError
+	}
+	if *format != formatText && *format != formatJSON && *format != formatSARIF {
+		fmt.Fprintf(stderr, "unknown format %q, the supported formats are [%s, %s, %s]\n", *format, formatText, formatJSON, formatSARIF)
+		return exitCodeError
+	}
+	if *resourceType != "" {
+		if err := azure.ValidateResourceType(*resourceType, *apiVersion); err != nil {
+			fmt.Fprintln(stderr, strings.TrimSpace(err.Error()))
+			return exitCodeError
+		}
+	}
+
+	findings := make([]Finding, 0)
+	upgraded := 0
+	for _, filename := range flags.Args() {
+		src, targets, diags := scanUpgradeTargets(filename, *resourceType, *apiVersion)
+		for _, diag := range diags {
+			fmt.Fprintln(stderr, diag.Error())
+		}
+		if diags.HasErrors() {
+			return exitCodeError
+		}
+		for _, target := range targets {
+			findings = append(findings, upgradeFindings(target)...)
+			if target.Body == nil {
+				fmt.Fprintf(stderr, "%s:%d:%d: %s: the body can't be validated offline against `%s`\n", filename, target.TypeRange.Start.Line, target.TypeRange.Start.Column, target.Address, target.Type)
+			}
+		}
+		if len(targets) == 0 || *dryRun {
+			continue
+		}
+		if err := writeUpgradedFile(filename, src, targets); err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+		upgraded += len(targets)
+	}
+	if !*dryRun {
+		fmt.Fprintf(stderr, "%d resources are upgraded to api-version %s\n", upgraded, *apiVersion)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+	if err := writeFindings(stdout, *format, findings); err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return exitCodeError
+	}
+	if len(findings) != 0 {
+		return exitCodeFindings
+	}
+	return exitCodeOK
+}
+
+// scanUpgradeTargets finds the `azapi_resource` blocks whose `type` is a literal and could be upgraded to the api-version.
+// The blocks are skipped if their resource types don't match the resource type filter, or don't have the api-version.
+// The blocks whose current api-versions are newer than the api-version are also skipped, a warning is returned for each of them.
+func scanUpgradeTargets(filename string, resourceType string, apiVersion string) ([]byte, []upgradeTarget, hcl.Diagnostics) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("reading %s: %+v", filename, err),
+		}}
+	}
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+
+	targets := make([]upgradeTarget, 0)
+	var warnings hcl.Diagnostics
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 || block.Labels[0] != "azapi_resource" {
+			continue
+		}
+		typeAttr, ok := block.Body.Attributes["type"]
+		if !ok {
+			continue
+		}
+		value, ok := literalString(typeAttr.Expr)
+		if !ok {
+			continue
+		}
+		currentType, currentApiVersion, _ := utils.SplitResourceType(value)
+		if currentApiVersion == "" || strings.EqualFold(currentApiVersion, apiVersion) {
+			continue
+		}
+		if resourceType != "" && !strings.EqualFold(currentType, resourceType) {
+			continue
+		}
+		if azure.ValidateResourceType(currentType, apiVersion) != nil {
+			continue
+		}
+		if azure.CompareApiVersions(currentApiVersion, apiVersion) > 0 {
+			rng := typeAttr.Expr.Range()
+			warnings = append(warnings, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  fmt.Sprintf("%s.%s: the current api-version %s is newer than %s, it's not downgraded", block.Labels[0], block.Labels[1], currentApiVersion, apiVersion),
+				Subject:  &rng,
+			})
+			continue
+		}
+		target := upgradeTarget{
+			Address:    fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1]),
+			Type:       fmt.Sprintf("%s@%s", currentType, apiVersion),
+			TypeRange:  typeAttr.Expr.Range(),
+			ApiVersion: currentApiVersion,
+		}
+		target.Body = scanResourceBlock(filename, block)
+		targets = append(targets, target)
+	}
+	return src, targets, warnings
+}
+
+// upgradeFindings validates the body against the target api-version. The properties which are valid in the current api-version
+// but not expected in the target api-version are reported as removed, or renamed if there're properties with similar names.
+func upgradeFindings(target upgradeTarget) []Finding {
+	if target.Body == nil {
+		return nil
+	}
+	resourceType, targetApiVersion, _ := utils.SplitResourceType(target.Type)
+	// the top level arguments are merged into the body, the same as the provider does in plan
+	rendered, err := services.RenderOfflineRequestBody(target.Body.requestBody())
+	if err != nil {
+		return []Finding{newFinding(*target.Body, target.Body.BodyRange, "", err.Error())}
+	}
+	notExpected := make(map[string]bool)
+	for _, err := range azure.ValidateBody(resourceType, target.ApiVersion, rendered) {
+		var validationErr *azureutils.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Kind == azureutils.ValidationErrorNotExpected {
+			notExpected[validationErr.Path] = true
+		}
+	}
+
+	body := *target.Body
+	body.Type = target.Type
+	findings := make([]Finding, 0)
+	for _, err := range azure.ValidateBody(resourceType, targetApiVersion, rendered) {
+		path, message := "", strings.TrimSpace(err.Error())
+		var validationErr *azureutils.ValidationError
+		if errors.As(err, &validationErr) {
+			path = validationErr.Path
+			if validationErr.Kind == azureutils.ValidationErrorNotExpected && !notExpected[path] {
+				if len(validationErr.Suggestions) != 0 {
+					message = fmt.Sprintf("`%s` is removed in api-version %s, it may be renamed to `%s`", path, targetApiVersion, strings.Join(validationErr.Suggestions, "` or `"))
+				} else {
+					message = fmt.Sprintf("`%s` is removed in api-version %s", path, targetApiVersion)
+				}
+			}
+		}
+		findings = append(findings, newFinding(body, body.locate(path), path, message))
+	}
+	return findings
+}
+
+// writeUpgradedFile replaces the `type` of the targets in the source, the other content of the file is kept as it is.
+func writeUpgradedFile(filename string, src []byte, targets []upgradeTarget) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].TypeRange.Start.Byte > targets[j].TypeRange.Start.Byte
+	})
+	output := make([]byte, len(src))
+	copy(output, src)
+	for _, target := range targets {
+		replaced := make([]byte, 0, len(output))
+		replaced = append(replaced, output[0:target.TypeRange.Start.Byte]...)
+		replaced = append(replaced, fmt.Sprintf("%q", target.Type)...)
+		replaced = append(replaced, output[target.TypeRange.End.Byte:]...)
+		output = replaced
+	}
+	return os.WriteFile(filename, output, info.Mode())
+}
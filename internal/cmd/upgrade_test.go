@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Upgrade(t *testing.T) {
+	filename := copyTestFile(t, "testdata/upgrade/main.tf")
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := Run([]string{"upgrade", "-api-version", "2021-09-01", "-format", "json", filename}, stdout, stderr)
+	if code != exitCodeFindings {
+		t.Fatalf("Expected exit code %d but got %d: %s", exitCodeFindings, code, stderr.String())
+	}
+
+	var output struct {
+		Findings []Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		Path    string
+		Line    int
+		Message string
+	}{
+		{
+			Path:    "properties.storageAccount",
+			Line:    12,
+			Message: "`properties.storageAccount` is removed in api-version 2021-09-01",
+		},
+		{
+			Path:    "properties.networkRuleSet.virtualNetworkRules",
+			Line:    17,
+			Message: "`properties.networkRuleSet.virtualNetworkRules` is removed in api-version 2021-09-01",
+		},
+	}
+	if len(output.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings but got %d: %v", len(expected), len(output.Findings), output.Findings)
+	}
+	for index, v := range expected {
+		actual := output.Findings[index]
+		t.Logf("[DEBUG] Testing Value %s", v.Path)
+		if actual.Address != "azapi_resource.registry" || actual.Path != v.Path || actual.Line != v.Line || actual.Message != v.Message {
+			t.Fatalf("Expected %v but got %v", v, actual)
+		}
+	}
+	if !strings.Contains(stderr.String(), "azapi_resource.dynamic: the body can't be validated offline") {
+		t.Fatalf("Expected the body of azapi_resource.dynamic to be skipped but got %q", stderr.String())
+	}
+
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the resource types which don't have the api-version are not upgraded
+	for _, v := range []string{
+		`type      = "Microsoft.ContainerRegistry/registries@2021-09-01"`,
+		`type      = "Microsoft.ServiceBus/namespaces@2021-06-01-preview"`,
+	} {
+		if !strings.Contains(string(src), v) {
+			t.Fatalf("Expected %q in the upgraded file but got %s", v, src)
+		}
+	}
+	if strings.Contains(string(src), "@2019-05-01") {
+		t.Fatalf("Expected all the registries to be upgraded but got %s", src)
+	}
+}
+
+func Test_UpgradeOptions(t *testing.T) {
+	testData := []struct {
+		Args      []string
+		Code      int
+		Unchanged bool
+		Stderr    string
+	}{
+		{
+			Args:      []string{"upgrade", "-api-version", "2021-09-01", "-dry-run"},
+			Code:      exitCodeFindings,
+			Unchanged: true,
+		},
+		{
+			Args:      []string{"upgrade", "-api-version", "2021-11-01", "-type", "Microsoft.ServiceBus/namespaces"},
+			Code:      exitCodeOK,
+			Unchanged: false,
+		},
+		{
+			// the api-versions are case-insensitive, the blocks which already use the api-version are not upgraded
+			Args:      []string{"upgrade", "-api-version", "2021-06-01-PREVIEW", "-type", "Microsoft.ServiceBus/namespaces"},
+			Code:      exitCodeOK,
+			Unchanged: true,
+		},
+		{
+			// the blocks are not downgraded to an older api-version
+			Args:      []string{"upgrade", "-api-version", "2021-01-01-preview", "-type", "Microsoft.ServiceBus/namespaces"},
+			Code:      exitCodeOK,
+			Unchanged: true,
+			Stderr:    "the current api-version 2021-06-01-preview is newer than 2021-01-01-preview, it's not downgraded",
+		},
+		{
+			Args:      []string{"upgrade", "-api-version", "2000-01-01", "-type", "Microsoft.ServiceBus/namespaces"},
+			Code:      exitCodeError,
+			Unchanged: true,
+		},
+		{
+			Args:      []string{"upgrade", "-api-version", "2021-09-01", "-format", "xml"},
+			Code:      exitCodeError,
+			Unchanged: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %v", v.Args)
+		filename := copyTestFile(t, "testdata/upgrade/main.tf")
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := Run(append(v.Args, filename), stdout, stderr)
+		if code != v.Code {
+			t.Fatalf("Expected exit code %d but got %d: %s", v.Code, code, stderr.String())
+		}
+		if !strings.Contains(stderr.String(), v.Stderr) {
+			t.Fatalf("Expected %q in stderr but got %q", v.Stderr, stderr.String())
+		}
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		original, err := os.ReadFile("testdata/upgrade/main.tf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if unchanged := bytes.Equal(src, original); unchanged != v.Unchanged {
+			t.Fatalf("Expected the file to be unchanged %t but got %t", v.Unchanged, unchanged)
+		}
+	}
+}
+
+func copyTestFile(t *testing.T, filename string) string {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), filepath.Base(filename))
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return output
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	azureutils "github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+	"github.com/Azure/terraform-provider-azapi/internal/services"
+	"github.com/Azure/terraform-provider-azapi/internal/services/rules"
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+func runValidate(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	resourceType := flags.String("type", "", "The resource type in a format like `<resource-type>@<api-version>`, it's used with -body.")
+	bodyFile := flags.String("body", "", "The path of the json body file, it's used with -type.")
+	format := flags.String("format", formatText, "The output format, possible values are `text`, `json` and `sarif`.")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: terraform-provider-azapi validate [options] [paths...]")
+		fmt.Fprintln(stderr, "")
+		fmt.Fprintln(stderr, "  Validates a json body file with -type and -body, or the literal bodies of the azapi_resource blocks in the .tf files under the paths.")
+		fmt.Fprintln(stderr, "")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *format != formatText && *format != formatJSON && *format != formatSARIF {
+		fmt.Fprintf(stderr, "unknown format %q, the supported formats are [%s, %s, %s]\n", *format, formatText, formatJSON, formatSARIF)
+		return exitCodeError
+	}
+
+	targets := make([]bodyTarget, 0)
+	switch {
+	case *resourceType != "" || *bodyFile != "":
+		if *resourceType == "" || *bodyFile == "" || flags.NArg() != 0 {
+			fmt.Fprintln(stderr, "-type and -body must be specified together, and paths are not allowed with them")
+			return exitCodeError
+		}
+		target, err := loadBodyFile(*bodyFile, *resourceType)
+		if err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return exitCodeError
+		}
+		targets = append(targets, *target)
+	default:
+		paths := flags.Args()
+		if len(paths) == 0 {
+			paths = []string{"."}
+		}
+		scanned, diags := scanPaths(paths)
+		if diags.HasErrors() {
+			for _, diag := range diags {
+				fmt.Fprintln(stderr, diag.Error())
+			}
+			return exitCodeError
+		}
+		targets = append(targets, scanned...)
+	}
+
+	ruleSet := rules.NewDefaultRuleSet()
+	findings := make([]Finding, 0)
+	for _, target := range targets {
+		findings = append(findings, validateTarget(target, ruleSet)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+	if err := writeFindings(stdout, *format, findings); err != nil {
+		fmt.Fprintln(stderr, err.Error())
+		return exitCodeError
+	}
+	if len(findings) != 0 {
+		return exitCodeFindings
+	}
+	return exitCodeOK
+}
+
+// validateTarget validates the body with the same rules as the provider's schema validation, it returns one finding for each error.
+func validateTarget(target bodyTarget, ruleSet *rules.RuleSet) []Finding {
+	resourceType, apiVersion, ok := utils.SplitResourceType(target.Type)
+	if !ok {
+		return []Finding{newFinding(target, target.TypeRange, "", "`type` is invalid, expect `<resource-type>@<api-version>`")}
+	}
+	findings := make([]Finding, 0)
+	if err := azure.ValidateResourceType(resourceType, apiVersion); err != nil {
+		findings = append(findings, newFinding(target, target.TypeRange, "", strings.TrimSpace(err.Error())))
+	}
+
+	// the cross-property rules are checked even if the resource type is unknown, the same as the provider's schema validation
+	for _, err := range services.ValidateRequestBody(target.requestBody(), ruleSet) {
+		path := ""
+		var validationErr *azureutils.ValidationError
+		if errors.As(err, &validationErr) {
+			path = validationErr.Path
+		}
+		findings = append(findings, newFinding(target, target.locate(path), path, strings.TrimSpace(err.Error())))
+	}
+	return findings
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_ValidateTerraformFiles(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := Run([]string{"validate", "-format", "json", "testdata/validate"}, stdout, stderr)
+	if code != exitCodeFindings {
+		t.Fatalf("Expected exit code %d but got %d: %s", exitCodeFindings, code, stderr.String())
+	}
+
+	var output struct {
+		Findings []Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		Address string
+		Path    string
+		Line    int
+		Column  int
+	}{
+		{
+			Address: "azapi_resource.test",
+			Path:    "sku.name",
+			Line:    8,
+			Column:  14,
+		},
+		{
+			Address: "azapi_resource.test",
+			Path:    "properties.adminUserEnabled",
+			Line:    11,
+			Column:  26,
+		},
+		{
+			Address: "azapi_resource.heredoc",
+			Path:    "properties.unknownProp",
+			Line:    27,
+			Column:  20,
+		},
+	}
+	if len(output.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings but got %d: %v", len(expected), len(output.Findings), output.Findings)
+	}
+	for index, v := range expected {
+		actual := output.Findings[index]
+		t.Logf("[DEBUG] Testing Value %s %s", v.Address, v.Path)
+		if actual.Address != v.Address || actual.Path != v.Path || actual.Line != v.Line || actual.Column != v.Column {
+			t.Fatalf("Expected %v but got %v", v, actual)
+		}
+	}
+}
+
+func Test_ValidateProviderDefaults(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := Run([]string{"validate", "-format", "json", "testdata/validate_provider"}, stdout, stderr)
+	if code != exitCodeFindings {
+		t.Fatalf("Expected exit code %d but got %d: %s", exitCodeFindings, code, stderr.String())
+	}
+
+	var output struct {
+		Findings []Finding `json:"findings"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+
+	// the default location is merged into the body, the resources using the provider with unknown defaults are skipped
+	expected := []struct {
+		Address string
+		Message string
+		Line    int
+	}{
+		{
+			Address: "azapi_resource.identity",
+			Message: "`identity.userAssignedIdentities` must be specified",
+			Line:    17,
+		},
+		{
+			Address: "azapi_resource.duplicated",
+			Message: "can't specify both property `tags` and `tags` in `body`",
+			Line:    34,
+		},
+	}
+	if len(output.Findings) != len(expected) {
+		t.Fatalf("Expected %d findings but got %d: %v", len(expected), len(output.Findings), output.Findings)
+	}
+	for index, v := range expected {
+		actual := output.Findings[index]
+		t.Logf("[DEBUG] Testing Value %s %s", v.Address, v.Message)
+		if actual.Address != v.Address || !strings.Contains(actual.Message, v.Message) || actual.Line != v.Line {
+			t.Fatalf("Expected %v but got %v", v, actual)
+		}
+	}
+}
+
+func Test_ValidateBodyFile(t *testing.T) {
+	testData := []struct {
+		Args     []string
+		Code     int
+		Expected string
+	}{
+		{
+			Args:     []string{"validate", "-type", "Microsoft.ContainerRegistry/registries@2020-11-01-preview", "-body", "testdata/validate/body.json"},
+			Code:     exitCodeFindings,
+			Expected: "testdata/validate/body.json:7:25: Microsoft.ContainerRegistry/registries@2020-11-01-preview: `properties.adminUserEnabled` is invalid, expect `bool` but got `string`",
+		},
+		{
+			Args:     []string{"validate", "-type", "Microsoft.ContainerRegistry/registries@2000-01-01", "-body", "testdata/validate/body.json"},
+			Code:     exitCodeFindings,
+			Expected: "the `type`'s api-version is invalid",
+		},
+		{
+			Args: []string{"validate", "-type", "Microsoft.ContainerRegistry/registries@2020-11-01-preview"},
+			Code: exitCodeError,
+		},
+		{
+			Args: []string{"validate", "-format", "xml", "testdata/validate"},
+			Code: exitCodeError,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %v", v.Args)
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := Run(v.Args, stdout, stderr)
+		if code != v.Code {
+			t.Fatalf("Expected exit code %d but got %d: %s", v.Code, code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), v.Expected) {
+			t.Fatalf("Expected %q in output but got %q", v.Expected, stdout.String())
+		}
+	}
+}
+
+func Test_ValidateSARIF(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if code := Run([]string{"validate", "-format", "sarif", "testdata/validate"}, stdout, stderr); code != exitCodeFindings {
+		t.Fatalf("Expected exit code %d but got %d: %s", exitCodeFindings, code, stderr.String())
+	}
+
+	var output struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleId    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							Uri string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+	if output.Version != "2.1.0" || len(output.Runs) != 1 || len(output.Runs[0].Results) != 3 {
+		t.Fatalf("Expected a SARIF 2.1.0 log with 3 results but got %s", stdout.String())
+	}
+	location := output.Runs[0].Results[0].Locations[0].PhysicalLocation
+	if location.ArtifactLocation.Uri != "testdata/validate/main.tf" || location.Region.StartLine != 8 {
+		t.Fatalf("Expected location testdata/validate/main.tf:8 but got %v", location)
+	}
+}
@@ -1,13 +1,34 @@
 package features
 
+import "github.com/Azure/terraform-provider-azapi/internal/services/rules"
+
+// DefaultOutdatedApiVersionThreshold is the default number of the newer stable api-versions which makes an api-version outdated.
+const DefaultOutdatedApiVersionThreshold = 5
+
 type UserFeatures struct {
-	DefaultTags     map[string]string
-	DefaultLocation string
+	DefaultTags              map[string]string
+	DefaultLocation          string
+	IgnoreTagKeys            []string
+	IgnoreTagKeyPrefixes     []string
+	DefaultParentId          string
+	DefaultResourceGroupName string
+	Policies                 *rules.PolicySet
+	Rules                    *rules.RuleSet
+
+	OutdatedApiVersionThreshold int
 }
 
 func Default() UserFeatures {
 	return UserFeatures{
-		DefaultTags:     nil,
-		DefaultLocation: "",
+		DefaultTags:              nil,
+		DefaultLocation:          "",
+		IgnoreTagKeys:            nil,
+		IgnoreTagKeyPrefixes:     nil,
+		DefaultParentId:          "",
+		DefaultResourceGroupName: "",
+		Policies:                 nil,
+		Rules:                    rules.NewDefaultRuleSet(),
+
+		OutdatedApiVersionThreshold: DefaultOutdatedApiVersionThreshold,
 	}
 }
@@ -0,0 +1,51 @@
+package functions
+
+import (
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ Function = BuildResourceIdFunction{}
+
+type BuildResourceIdFunction struct{}
+
+func (f BuildResourceIdFunction) Definition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Summary:     "Builds an Azure resource ID",
+		Description: "Builds an Azure resource ID from the parent ID, the resource type and the name, the same as how `azapi_resource` builds its ID.",
+		Parameters: []*tfprotov5.FunctionParameter{
+			stringParameter("parent_id", "The ID of the azure resource in which the resource is created."),
+			stringParameter("resource_type", "The resource type, for example, `Microsoft.Network/virtualNetworks`. It can also be in a format like `<resource-type>@<api-version>`, which is required if the resource type can't be found in the embedded schemas."),
+			stringParameter("name", "The name of the resource."),
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+}
+
+func (f BuildResourceIdFunction) Run(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	parentId, funcErr := stringArgument(arguments, 0)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	resourceType, funcErr := stringArgument(arguments, 1)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	name, funcErr := stringArgument(arguments, 2)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+
+	resourceType, err := parse.ResourceTypeWithApiVersion(resourceType)
+	if err != nil {
+		return tftypes.Value{}, &tfprotov5.FunctionError{Text: err.Error()}
+	}
+	id, err := parse.BuildResourceID(name, parentId, resourceType)
+	if err != nil {
+		return tftypes.Value{}, &tfprotov5.FunctionError{Text: err.Error()}
+	}
+	return tftypes.NewValue(tftypes.String, id.AzureResourceId), nil
+}
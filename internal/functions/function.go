@@ -0,0 +1,105 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Function is a provider defined function which can be called in the configuration like `provider::azapi::<name>(...)`.
+type Function interface {
+	Definition() *tfprotov5.Function
+	Run(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError)
+}
+
+func Functions() map[string]Function {
+	return map[string]Function{
+		"parse_resource_id":          ParseResourceIdFunction{},
+		"build_resource_id":          BuildResourceIdFunction{},
+		"subscription_resource_id":   SubscriptionResourceIdFunction{},
+		"resource_group_resource_id": ResourceGroupResourceIdFunction{},
+		"tenant_resource_id":         TenantResourceIdFunction{},
+		"extension_resource_id":      ExtensionResourceIdFunction{},
+	}
+}
+
+// Call decodes the arguments according to the function's parameters, then runs the function and encodes the result.
+func Call(function Function, arguments []*tfprotov5.DynamicValue) (*tfprotov5.DynamicValue, *tfprotov5.FunctionError) {
+	definition := function.Definition()
+	if len(arguments) != len(definition.Parameters) {
+		return nil, &tfprotov5.FunctionError{
+			Text: fmt.Sprintf("expect %d arguments, but got %d", len(definition.Parameters), len(arguments)),
+		}
+	}
+
+	values := make([]tftypes.Value, 0)
+	for index, argument := range arguments {
+		value, err := argument.Unmarshal(definition.Parameters[index].Type)
+		if err != nil {
+			return nil, argumentError(index, "failed to decode argument: %+v", err)
+		}
+		values = append(values, value)
+	}
+
+	result, funcErr := function.Run(values)
+	if funcErr != nil {
+		return nil, funcErr
+	}
+
+	output, err := tfprotov5.NewDynamicValue(definition.Return.Type, result)
+	if err != nil {
+		return nil, &tfprotov5.FunctionError{
+			Text: fmt.Sprintf("failed to encode result: %+v", err),
+		}
+	}
+	return &output, nil
+}
+
+func argumentError(index int, format string, a ...interface{}) *tfprotov5.FunctionError {
+	argument := int64(index)
+	return &tfprotov5.FunctionError{
+		Text:             fmt.Sprintf(format, a...),
+		FunctionArgument: &argument,
+	}
+}
+
+func stringParameter(name string, description string) *tfprotov5.FunctionParameter {
+	return &tfprotov5.FunctionParameter{
+		Name:        name,
+		Description: description,
+		Type:        tftypes.String,
+	}
+}
+
+func stringListParameter(name string, description string) *tfprotov5.FunctionParameter {
+	return &tfprotov5.FunctionParameter{
+		Name:        name,
+		Description: description,
+		Type:        tftypes.List{ElementType: tftypes.String},
+	}
+}
+
+func stringArgument(arguments []tftypes.Value, index int) (string, *tfprotov5.FunctionError) {
+	var value string
+	if err := arguments[index].As(&value); err != nil {
+		return "", argumentError(index, "expect a string: %+v", err)
+	}
+	return value, nil
+}
+
+func stringListArgument(arguments []tftypes.Value, index int) ([]string, *tfprotov5.FunctionError) {
+	var elements []tftypes.Value
+	if err := arguments[index].As(&elements); err != nil {
+		return nil, argumentError(index, "expect a list of string: %+v", err)
+	}
+	values := make([]string, 0)
+	for _, element := range elements {
+		var value string
+		if err := element.As(&value); err != nil {
+			return nil, argumentError(index, "expect a list of string: %+v", err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
@@ -0,0 +1,262 @@
+package functions_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/functions"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceIdTestCase is the format of the test cases in utils/testdata/resource_ids.json, they're shared with the utils' tests.
+type resourceIdTestCase struct {
+	Id                string            `json:"id"`
+	ParentId          string            `json:"parent_id"`
+	ResourceType      string            `json:"resource_type"`
+	Name              string            `json:"name"`
+	ScopeType         string            `json:"scope_type"`
+	SubscriptionId    string            `json:"subscription_id"`
+	ResourceGroupName string            `json:"resource_group_name"`
+	ProviderNamespace string            `json:"provider_namespace"`
+	Parts             map[string]string `json:"parts"`
+}
+
+func call(t *testing.T, name string, arguments ...tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	function, ok := functions.Functions()[name]
+	if !ok {
+		t.Fatalf("function %s is not registered", name)
+	}
+	definition := function.Definition()
+	input := make([]*tfprotov5.DynamicValue, 0)
+	for index, argument := range arguments {
+		value, err := tfprotov5.NewDynamicValue(definition.Parameters[index].Type, argument)
+		if err != nil {
+			t.Fatal(err)
+		}
+		input = append(input, &value)
+	}
+	output, funcErr := functions.Call(function, input)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	result, err := output.Unmarshal(definition.Return.Type)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result, nil
+}
+
+func stringValue(input string) tftypes.Value {
+	return tftypes.NewValue(tftypes.String, input)
+}
+
+func stringListValue(input []string) tftypes.Value {
+	values := make([]tftypes.Value, 0)
+	for _, v := range input {
+		values = append(values, stringValue(v))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+}
+
+func Test_ParseResourceId(t *testing.T) {
+	data, err := os.ReadFile("../../utils/testdata/resource_ids.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []resourceIdTestCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Id)
+
+		result, funcErr := call(t, "parse_resource_id", stringValue(tc.Id))
+		if funcErr != nil {
+			t.Fatalf("Expected no error but got %s", funcErr.Text)
+		}
+
+		var attributes map[string]tftypes.Value
+		if err := result.As(&attributes); err != nil {
+			t.Fatal(err)
+		}
+		output := resourceIdTestCase{}
+		for key, target := range map[string]*string{
+			"id":                  &output.Id,
+			"parent_id":           &output.ParentId,
+			"type":                &output.ResourceType,
+			"name":                &output.Name,
+			"scope_type":          &output.ScopeType,
+			"subscription_id":     &output.SubscriptionId,
+			"resource_group_name": &output.ResourceGroupName,
+			"provider_namespace":  &output.ProviderNamespace,
+		} {
+			if err := attributes[key].As(target); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var parts map[string]tftypes.Value
+		if err := attributes["parts"].As(&parts); err != nil {
+			t.Fatal(err)
+		}
+		output.Parts = make(map[string]string)
+		for key, value := range parts {
+			var v string
+			if err := value.As(&v); err != nil {
+				t.Fatal(err)
+			}
+			output.Parts[key] = v
+		}
+
+		if !reflect.DeepEqual(output, tc) {
+			t.Fatalf("Expected %v but got %v", tc, output)
+		}
+	}
+}
+
+func Test_ParseResourceIdInvalid(t *testing.T) {
+	for _, input := range []string{"invalid", "subscriptions/00000000-0000-0000-0000-000000000000"} {
+		t.Logf("[DEBUG] Testing Value %s", input)
+		if _, funcErr := call(t, "parse_resource_id", stringValue(input)); funcErr == nil {
+			t.Fatalf("Expected an error but got none")
+		}
+	}
+}
+
+func Test_BuildResourceId(t *testing.T) {
+	data, err := os.ReadFile("../../utils/testdata/resource_ids.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []resourceIdTestCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		// subscriptions can't be built from a parent id and a resource type
+		if tc.ResourceType == "Subscription" {
+			continue
+		}
+		t.Logf("[DEBUG] Testing Value %s", tc.Id)
+
+		// the api-version is required for the resource types which can't be found in the embedded schemas
+		resourceType := tc.ResourceType
+		if len(azure.GetApiVersions(resourceType)) == 0 {
+			resourceType += "@2020-01-01"
+		}
+		result, funcErr := call(t, "build_resource_id", stringValue(tc.ParentId), stringValue(resourceType), stringValue(tc.Name))
+		if funcErr != nil {
+			t.Fatalf("Expected no error but got %s", funcErr.Text)
+		}
+		var output string
+		if err := result.As(&output); err != nil {
+			t.Fatal(err)
+		}
+		if output != tc.Id {
+			t.Fatalf("Expected %s but got %s", tc.Id, output)
+		}
+	}
+}
+
+func Test_BuildResourceIdInvalidScope(t *testing.T) {
+	// virtual networks can't be deployed at the subscription scope
+	_, funcErr := call(t, "build_resource_id",
+		stringValue("/subscriptions/00000000-0000-0000-0000-000000000000"),
+		stringValue("Microsoft.Network/virtualNetworks@2021-02-01"),
+		stringValue("vnet1"))
+	if funcErr == nil {
+		t.Fatalf("Expected an error but got none")
+	}
+}
+
+func Test_BuildResourceIdUnknownType(t *testing.T) {
+	_, funcErr := call(t, "build_resource_id",
+		stringValue("/subscriptions/00000000-0000-0000-0000-000000000000"),
+		stringValue("Microsoft.Foo/bars"),
+		stringValue("bar1"))
+	if funcErr == nil {
+		t.Fatalf("Expected an error but got none")
+	}
+}
+
+func Test_ScopedResourceIds(t *testing.T) {
+	cases := []struct {
+		Function  string
+		Arguments []tftypes.Value
+		Output    string
+		Error     bool
+	}{
+		{
+			Function:  "subscription_resource_id",
+			Arguments: []tftypes.Value{stringValue("00000000-0000-0000-0000-000000000000"), stringValue("Microsoft.Resources/resourceGroups"), stringListValue([]string{"rg1"})},
+			Output:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1",
+		},
+		{
+			Function:  "subscription_resource_id",
+			Arguments: []tftypes.Value{stringValue("00000000-0000-0000-0000-000000000000"), stringValue("Microsoft.Authorization/policyDefinitions"), stringListValue([]string{"def1"})},
+			Output:    "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/policyDefinitions/def1",
+		},
+		{
+			Function:  "subscription_resource_id",
+			Arguments: []tftypes.Value{stringValue(""), stringValue("Microsoft.Resources/resourceGroups"), stringListValue([]string{"rg1"})},
+			Error:     true,
+		},
+		{
+			Function:  "resource_group_resource_id",
+			Arguments: []tftypes.Value{stringValue("00000000-0000-0000-0000-000000000000"), stringValue("rg1"), stringValue("Microsoft.Network/virtualNetworks/subnets"), stringListValue([]string{"vnet1", "subnet1"})},
+			Output:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1",
+		},
+		{
+			Function:  "resource_group_resource_id",
+			Arguments: []tftypes.Value{stringValue("00000000-0000-0000-0000-000000000000"), stringValue("rg1"), stringValue("Microsoft.Network/virtualNetworks/subnets"), stringListValue([]string{"vnet1"})},
+			Error:     true,
+		},
+		{
+			Function:  "tenant_resource_id",
+			Arguments: []tftypes.Value{stringValue("Microsoft.Management/managementGroups"), stringListValue([]string{"mg1"})},
+			Output:    "/providers/Microsoft.Management/managementGroups/mg1",
+		},
+		{
+			Function:  "tenant_resource_id",
+			Arguments: []tftypes.Value{stringValue("Microsoft.Management/managementGroups"), stringListValue([]string{""})},
+			Error:     true,
+		},
+		{
+			Function:  "extension_resource_id",
+			Arguments: []tftypes.Value{stringValue("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1"), stringValue("Microsoft.Authorization/locks"), stringListValue([]string{"lock1"})},
+			Output:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Authorization/locks/lock1",
+		},
+		{
+			Function:  "extension_resource_id",
+			Arguments: []tftypes.Value{stringValue("invalid"), stringValue("Microsoft.Authorization/locks"), stringListValue([]string{"lock1"})},
+			Error:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s %v", tc.Function, tc.Arguments)
+
+		result, funcErr := call(t, tc.Function, tc.Arguments...)
+		if tc.Error {
+			if funcErr == nil {
+				t.Fatalf("Expected an error but got none")
+			}
+			continue
+		}
+		if funcErr != nil {
+			t.Fatalf("Expected no error but got %s", funcErr.Text)
+		}
+		var output string
+		if err := result.As(&output); err != nil {
+			t.Fatal(err)
+		}
+		if output != tc.Output {
+			t.Fatalf("Expected %s but got %s", tc.Output, output)
+		}
+	}
+}
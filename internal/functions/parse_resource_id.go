@@ -0,0 +1,67 @@
+package functions
+
+import (
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ Function = ParseResourceIdFunction{}
+
+var resourceIdObjectType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":                  tftypes.String,
+		"type":                tftypes.String,
+		"name":                tftypes.String,
+		"parent_id":           tftypes.String,
+		"scope_type":          tftypes.String,
+		"subscription_id":     tftypes.String,
+		"resource_group_name": tftypes.String,
+		"provider_namespace":  tftypes.String,
+		"parts":               tftypes.Map{ElementType: tftypes.String},
+	},
+}
+
+type ParseResourceIdFunction struct{}
+
+func (f ParseResourceIdFunction) Definition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Summary:     "Parses an Azure resource ID",
+		Description: "Parses an Azure resource ID into its components: `type`, `name`, `parent_id`, `scope_type`, `subscription_id`, `resource_group_name`, `provider_namespace` and `parts`.",
+		Parameters: []*tfprotov5.FunctionParameter{
+			stringParameter("resource_id", "The Azure resource ID."),
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: resourceIdObjectType,
+		},
+	}
+}
+
+func (f ParseResourceIdFunction) Run(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	id, funcErr := stringArgument(arguments, 0)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+
+	resourceType := utils.GetResourceType(id)
+	if resourceType == "" {
+		return tftypes.Value{}, argumentError(0, "resource id %q is invalid", id)
+	}
+
+	parts := make(map[string]tftypes.Value)
+	for key, value := range utils.GetIdParts(id) {
+		parts[key] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	return tftypes.NewValue(resourceIdObjectType, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, id),
+		"type":                tftypes.NewValue(tftypes.String, resourceType),
+		"name":                tftypes.NewValue(tftypes.String, utils.GetName(id)),
+		"parent_id":           tftypes.NewValue(tftypes.String, utils.GetParentId(id)),
+		"scope_type":          tftypes.NewValue(tftypes.String, utils.GetScopeType(id).String()),
+		"subscription_id":     tftypes.NewValue(tftypes.String, utils.GetSubscriptionId(id)),
+		"resource_group_name": tftypes.NewValue(tftypes.String, utils.GetResourceGroupName(id)),
+		"provider_namespace":  tftypes.NewValue(tftypes.String, utils.GetProviderNamespace(resourceType)),
+		"parts":               tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, parts),
+	}), nil
+}
@@ -0,0 +1,147 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ Function = SubscriptionResourceIdFunction{}
+	_ Function = ResourceGroupResourceIdFunction{}
+	_ Function = TenantResourceIdFunction{}
+	_ Function = ExtensionResourceIdFunction{}
+)
+
+const resourceNamesDescription = "The names of the resource and its parent resources, for example, `[\"vnet1\", \"subnet1\"]` for `Microsoft.Network/virtualNetworks/subnets`."
+
+type SubscriptionResourceIdFunction struct{}
+
+func (f SubscriptionResourceIdFunction) Definition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Summary:     "Builds the ID of a subscription scoped resource",
+		Description: "Builds the ID of a resource which is deployed at the subscription scope.",
+		Parameters: []*tfprotov5.FunctionParameter{
+			stringParameter("subscription_id", "The ID of the subscription."),
+			stringParameter("resource_type", "The resource type, for example, `Microsoft.Resources/resourceGroups`."),
+			stringListParameter("resource_names", resourceNamesDescription),
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+}
+
+func (f SubscriptionResourceIdFunction) Run(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	subscriptionId, funcErr := stringArgument(arguments, 0)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	if subscriptionId == "" {
+		return tftypes.Value{}, argumentError(0, "subscription id must not be empty")
+	}
+	return buildResourceIdWithScope(fmt.Sprintf("/subscriptions/%s", subscriptionId), arguments, 1)
+}
+
+type ResourceGroupResourceIdFunction struct{}
+
+func (f ResourceGroupResourceIdFunction) Definition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Summary:     "Builds the ID of a resource group scoped resource",
+		Description: "Builds the ID of a resource which is deployed at the resource group scope.",
+		Parameters: []*tfprotov5.FunctionParameter{
+			stringParameter("subscription_id", "The ID of the subscription."),
+			stringParameter("resource_group_name", "The name of the resource group."),
+			stringParameter("resource_type", "The resource type, for example, `Microsoft.Network/virtualNetworks`."),
+			stringListParameter("resource_names", resourceNamesDescription),
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+}
+
+func (f ResourceGroupResourceIdFunction) Run(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	subscriptionId, funcErr := stringArgument(arguments, 0)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	if subscriptionId == "" {
+		return tftypes.Value{}, argumentError(0, "subscription id must not be empty")
+	}
+	resourceGroupName, funcErr := stringArgument(arguments, 1)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	if resourceGroupName == "" {
+		return tftypes.Value{}, argumentError(1, "resource group name must not be empty")
+	}
+	return buildResourceIdWithScope(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionId, resourceGroupName), arguments, 2)
+}
+
+type TenantResourceIdFunction struct{}
+
+func (f TenantResourceIdFunction) Definition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Summary:     "Builds the ID of a tenant scoped resource",
+		Description: "Builds the ID of a resource which is deployed at the tenant scope.",
+		Parameters: []*tfprotov5.FunctionParameter{
+			stringParameter("resource_type", "The resource type, for example, `Microsoft.Management/managementGroups`."),
+			stringListParameter("resource_names", resourceNamesDescription),
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+}
+
+func (f TenantResourceIdFunction) Run(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	return buildResourceIdWithScope("", arguments, 0)
+}
+
+type ExtensionResourceIdFunction struct{}
+
+func (f ExtensionResourceIdFunction) Definition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Summary:     "Builds the ID of an extension resource",
+		Description: "Builds the ID of an extension resource, which is applied to another resource to add its capabilities.",
+		Parameters: []*tfprotov5.FunctionParameter{
+			stringParameter("base_resource_id", "The ID of the resource which the extension resource is applied to."),
+			stringParameter("resource_type", "The resource type, for example, `Microsoft.Authorization/locks`."),
+			stringListParameter("resource_names", resourceNamesDescription),
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+}
+
+func (f ExtensionResourceIdFunction) Run(arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	baseResourceId, funcErr := stringArgument(arguments, 0)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	if utils.GetResourceType(baseResourceId) == "" {
+		return tftypes.Value{}, argumentError(0, "base resource id %q is invalid", baseResourceId)
+	}
+	return buildResourceIdWithScope(baseResourceId, arguments, 1)
+}
+
+// buildResourceIdWithScope builds the resource id from the scope and the arguments starting from `index`, which are the resource type and names.
+func buildResourceIdWithScope(scopeId string, arguments []tftypes.Value, index int) (tftypes.Value, *tfprotov5.FunctionError) {
+	resourceType, funcErr := stringArgument(arguments, index)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	names, funcErr := stringListArgument(arguments, index+1)
+	if funcErr != nil {
+		return tftypes.Value{}, funcErr
+	}
+	id, err := utils.BuildResourceIdWithScope(scopeId, resourceType, names)
+	if err != nil {
+		return tftypes.Value{}, &tfprotov5.FunctionError{Text: err.Error()}
+	}
+	return tftypes.NewValue(tftypes.String, id), nil
+}
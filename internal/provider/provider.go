@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
@@ -15,6 +14,8 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/features"
 	"github.com/Azure/terraform-provider-azapi/internal/services"
+	"github.com/Azure/terraform-provider-azapi/internal/services/rules"
+	"github.com/Azure/terraform-provider-azapi/internal/services/validate"
 	"github.com/Azure/terraform-provider-azapi/version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,8 +33,11 @@ func azureProvider() *schema.Provider {
 
 	resources["azapi_resource"] = services.ResourceAzureGenericResource()
 	resources["azapi_patch_resource"] = services.ResourceAzureGenericPatchResource()
+	resources["azapi_data_plane_resource"] = services.ResourceAzureDataPlaneResource()
 
 	dataSources["azapi_resource"] = services.ResourceAzureGenericDataSource()
+	dataSources["azapi_resource_id"] = services.ResourceAzureGenericResourceIdDataSource()
+	dataSources["azapi_client_config"] = services.ResourceAzureClientConfigDataSource()
 
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -133,6 +137,54 @@ func azureProvider() *schema.Provider {
 			"default_location": location.SchemaLocation(),
 
 			"default_tags": tags.SchemaTags(),
+
+			"ignore_tags": tags.SchemaIgnoreTags(),
+
+			"default_parent_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.AzureResourceID,
+				Description:  "The default ID of the azure resource in which the resources are created when `parent_id` is omitted.",
+			},
+
+			"default_resource_group_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The default name of the resource group in which the resources are created when `parent_id` is omitted. It requires `subscription_id` to be specified.",
+			},
+
+			"schema_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(azure.SchemaPathEnvName, ""),
+				Description: "The path of a directory or a zip archive of the bicep type files, which are overlaid on the embedded schemas. The external resource definitions take precedence over the embedded ones with the same resource type and api-version.",
+			},
+
+			"validation_rules_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(rules.PathEnvName, ""),
+				Description: "The path of a HCL or JSON file of the cross-property rules, which are checked after the body passes the schema validation. The conditions of the rules are written in CEL.",
+			},
+
+			"policy_paths": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "The paths of the policy files or the directories of the policy files, which are evaluated against the bodies of the `azapi_resource` during plan. The policies are HCL or JSON files whose conditions are written in CEL.",
+			},
+
+			"outdated_api_version_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      features.DefaultOutdatedApiVersionThreshold,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of the newer stable api-versions which makes the api-version of an `azapi_resource` outdated. A warning is emitted during plan if the api-version is outdated. Defaults to `5`.",
+			},
 		},
 
 		DataSourcesMap: dataSources,
@@ -184,15 +236,46 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.Errorf("failed to obtain a credential: %v", err)
 		}
 
+		if d.Get("default_resource_group_name").(string) != "" && d.Get("subscription_id").(string) == "" {
+			return nil, diag.Errorf("`subscription_id` must be specified when `default_resource_group_name` is specified")
+		}
+
+		ignoreTagKeys, ignoreTagKeyPrefixes := tags.ExpandIgnoreTags(d.Get("ignore_tags").([]interface{}))
+
+		policyPaths := make([]string, 0)
+		for _, path := range d.Get("policy_paths").([]interface{}) {
+			policyPaths = append(policyPaths, path.(string))
+		}
+		policies, err := rules.LoadPolicies(policyPaths)
+		if err != nil {
+			return nil, diag.Errorf("failed to load `policy_paths`: %+v", err)
+		}
+
+		ruleSet := rules.NewDefaultRuleSet()
+		if rulesPath := d.Get("validation_rules_path").(string); rulesPath != "" {
+			if _, err := ruleSet.LoadFile(rulesPath); err != nil {
+				return nil, diag.Errorf("failed to load `validation_rules_path`: %+v", err)
+			}
+		}
+
 		copt := &clients.Option{
 			SubscriptionId: d.Get("subscription_id").(string),
 			Cred:           cred,
 			//AuxiliaryTenantIDs:   auxTenants,
 			ApplicationUserAgent: buildUserAgent(p.TerraformVersion),
 			ARMEndpoint:          armEndpoint,
+			Environment:          strings.ToLower(env),
 			Features: features.UserFeatures{
-				DefaultTags:     tags.ExpandTags(d.Get("default_tags").(map[string]interface{})),
-				DefaultLocation: location.Normalize(d.Get("default_location").(string)),
+				DefaultTags:              tags.ExpandTags(d.Get("default_tags").(map[string]interface{})),
+				DefaultLocation:          location.Normalize(d.Get("default_location").(string)),
+				IgnoreTagKeys:            ignoreTagKeys,
+				IgnoreTagKeyPrefixes:     ignoreTagKeyPrefixes,
+				DefaultParentId:          d.Get("default_parent_id").(string),
+				DefaultResourceGroupName: d.Get("default_resource_group_name").(string),
+				Policies:                 policies,
+				Rules:                    ruleSet,
+
+				OutdatedApiVersionThreshold: d.Get("outdated_api_version_threshold").(int),
 			},
 			SkipProviderRegistration: d.Get("skip_provider_registration").(bool),
 		}
@@ -209,11 +292,22 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 		}
 
 		// load schema
-		var mutex sync.Mutex
-		mutex.Lock()
 		azure.GetAzureSchema()
-		mutex.Unlock()
-		return client, nil
+
+		var diags diag.Diagnostics
+		if schemaPath := d.Get("schema_path").(string); schemaPath != "" {
+			external, err := azure.LoadExternalSchema(schemaPath)
+			if err != nil {
+				return nil, diag.Errorf("failed to load `schema_path`: %+v", err)
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "External schemas are used",
+				Detail: fmt.Sprintf("The schemas in %s are overlaid on the embedded schemas, %d resource definitions are added and %d embedded resource definitions are overridden. "+
+					"The body validation errors of the resource definitions from the external schemas contain the path of the schemas.", external.Path, external.Added, external.Overridden),
+			})
+		}
+		return client, diags
 	}
 }
 
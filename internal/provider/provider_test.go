@@ -1,9 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 func TestProvider(t *testing.T) {
@@ -13,11 +21,22 @@ func TestProvider(t *testing.T) {
 }
 
 func TestDataSourcesSupportCustomTimeouts(t *testing.T) {
+	// the data sources which only parse their arguments make no network calls, so they have no timeouts
+	offlineDataSources := map[string]bool{
+		"azapi_resource_id": true,
+	}
 	provider := AzureProvider()
 	for dataSourceName, dataSource := range provider.DataSourcesMap {
 		t.Run(fmt.Sprintf("DataSource/%s", dataSourceName), func(t *testing.T) {
 			t.Logf("[DEBUG] Testing Data Source %q..", dataSourceName)
 
+			if offlineDataSources[dataSourceName] {
+				if dataSource.Timeouts != nil {
+					t.Fatalf("Data Source %q makes no network calls but defines timeouts!", dataSourceName)
+				}
+				return
+			}
+
 			if dataSource.Timeouts == nil {
 				t.Fatalf("Data Source %q has no timeouts block defined!", dataSourceName)
 			}
@@ -89,3 +108,203 @@ func TestResourcesSupportCustomTimeouts(t *testing.T) {
 func TestProvider_impl(t *testing.T) {
 	_ = AzureProvider()
 }
+
+func TestProviderServerFunctions(t *testing.T) {
+	server := AzureProviderServer()
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	metadataResp, err := server.GetMetadata(context.Background(), &tfprotov5.GetMetadataRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(schemaResp.Functions) == 0 || len(schemaResp.Functions) != len(metadataResp.Functions) {
+		t.Fatalf("Expected functions in both schema and metadata, but got %d and %d", len(schemaResp.Functions), len(metadataResp.Functions))
+	}
+	if _, ok := schemaResp.ResourceSchemas["azapi_resource"]; !ok {
+		t.Fatalf("Expected resource azapi_resource in schema")
+	}
+
+	argument, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, "Microsoft.Management/managementGroups"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	names, err := tfprotov5.NewDynamicValue(tftypes.List{ElementType: tftypes.String}, tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "mg1")}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	callResp, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+		Name:      "tenant_resource_id",
+		Arguments: []*tfprotov5.DynamicValue{&argument, &names},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if callResp.Error != nil {
+		t.Fatalf("err: %s", callResp.Error.Text)
+	}
+	result, err := callResp.Result.Unmarshal(tftypes.String)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var id string
+	if err := result.As(&id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if expected := "/providers/Microsoft.Management/managementGroups/mg1"; id != expected {
+		t.Fatalf("Expected %s but got %s", expected, id)
+	}
+
+	callResp, err = server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{Name: "unknown"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if callResp.Error == nil {
+		t.Fatalf("Expected an error for unknown function")
+	}
+}
+
+func TestProviderServerMoveResourceState(t *testing.T) {
+	server := AzureProviderServer()
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	metadataResp, err := server.GetMetadata(context.Background(), &tfprotov5.GetMetadataRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if schemaResp.ServerCapabilities == nil || !schemaResp.ServerCapabilities.MoveResourceState || metadataResp.ServerCapabilities == nil || !metadataResp.ServerCapabilities.MoveResourceState {
+		t.Fatalf("Expected the MoveResourceState capability in both schema and metadata")
+	}
+	if !schemaResp.ServerCapabilities.GetProviderSchemaOptional {
+		t.Fatalf("Expected the capabilities of the plugin SDK to be kept")
+	}
+
+	// the unsupported source resources are rejected before calling Azure
+	moveResp, err := server.MoveResourceState(context.Background(), &tfprotov5.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+		SourceTypeName:        "azurerm_key_vault_secret",
+		SourceState: &tfprotov5.RawState{
+			JSON: []byte(`{"id": "https://vault1.vault.azure.net/secrets/secret1/00000000000000000000000000000000"}`),
+		},
+		TargetTypeName: "azapi_resource",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(moveResp.Diagnostics) != 1 || moveResp.Diagnostics[0].Severity != tfprotov5.DiagnosticSeverityError || moveResp.TargetState != nil {
+		t.Fatalf("Expected an error diagnostic but got %v", moveResp.Diagnostics)
+	}
+}
+
+func TestAttributePath(t *testing.T) {
+	testData := []struct {
+		Input    cty.Path
+		Expected *tftypes.AttributePath
+	}{
+		{
+			Input:    nil,
+			Expected: nil,
+		},
+		{
+			Input:    cty.GetAttrPath("body"),
+			Expected: tftypes.NewAttributePath().WithAttributeName("body"),
+		},
+		{
+			Input:    cty.GetAttrPath("identity").IndexInt(0),
+			Expected: nil,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %#v", v.Input)
+		actual := attributePath(v.Input)
+		if (actual == nil) != (v.Expected == nil) || (actual != nil && !actual.Equal(v.Expected)) {
+			t.Fatalf("Expected %v but got %v", v.Expected, actual)
+		}
+	}
+}
+
+func TestAppendPlanDiagnostics(t *testing.T) {
+	sdkError := &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  services.ErrPlanDiagnostics.Error(),
+	}
+	otherError := &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  "other error",
+	}
+	testData := []struct {
+		Input     []*tfprotov5.Diagnostic
+		Collected diag.Diagnostics
+		Expected  []string
+	}{
+		{
+			Input: []*tfprotov5.Diagnostic{otherError},
+			Collected: diag.Diagnostics{
+				{Severity: diag.Warning, Summary: "warning"},
+			},
+			Expected: []string{"other error", "warning"},
+		},
+		{
+			// each of the collected errors is reported separately
+			Input: []*tfprotov5.Diagnostic{sdkError},
+			Collected: diag.Diagnostics{
+				{Severity: diag.Error, Summary: "error 1"},
+				{Severity: diag.Error, Summary: "error 2"},
+				{Severity: diag.Warning, Summary: "warning"},
+			},
+			Expected: []string{"error 1", "error 2", "warning"},
+		},
+		{
+			// the placeholder error is kept when no error is collected
+			Input:    []*tfprotov5.Diagnostic{sdkError},
+			Expected: []string{services.ErrPlanDiagnostics.Error()},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %v", v.Collected)
+		actual := make([]string, 0)
+		for _, d := range appendPlanDiagnostics(v.Input, v.Collected) {
+			actual = append(actual, d.Summary)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(v.Expected) {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestStateWithId(t *testing.T) {
+	ty := services.ResourceAzureGenericResource().CoreConfigSchema().ImpliedType()
+	attributes := make(map[string]cty.Value)
+	for name, attributeType := range ty.AttributeTypes() {
+		attributes[name] = cty.NullVal(attributeType)
+	}
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet1"
+	attributes["id"] = cty.StringVal(id + "?api-version=2021-02-01")
+	attributes["type"] = cty.StringVal("Microsoft.Network/virtualNetworks@2021-02-01")
+	data, err := msgpack.Marshal(cty.ObjectVal(attributes), ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := stateWithId(&tfprotov5.DynamicValue{MsgPack: data}, id)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	value, err := msgpack.Unmarshal(state.MsgPack, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := value.GetAttr("id").AsString(); actual != id {
+		t.Fatalf("Expected %q but got %q", id, actual)
+	}
+	if actual := value.GetAttr("type").AsString(); actual != "Microsoft.Network/virtualNetworks@2021-02-01" {
+		t.Fatalf("Expected the other attributes to be kept but got %q", actual)
+	}
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Azure/terraform-provider-azapi/internal/functions"
+	"github.com/Azure/terraform-provider-azapi/internal/services"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var _ tfprotov5.ProviderServer = &ProviderServer{}
+
+// ProviderServer serves the provider built by the plugin SDK, and the provider defined functions which aren't supported by the plugin SDK.
+type ProviderServer struct {
+	tfprotov5.ProviderServer
+	functions map[string]functions.Function
+}
+
+func AzureProviderServer() tfprotov5.ProviderServer {
+	return &ProviderServer{
+		ProviderServer: schema.NewGRPCProviderServer(AzureProvider()),
+		functions:      functions.Functions(),
+	}
+}
+
+func (s *ProviderServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	names := make([]string, 0)
+	for name := range s.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resp.Functions = append(resp.Functions, tfprotov5.FunctionMetadata{Name: name})
+	}
+	resp.ServerCapabilities = serverCapabilities(resp.ServerCapabilities)
+	return resp, nil
+}
+
+func (s *ProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	resp.Functions = s.definitions()
+	resp.ServerCapabilities = serverCapabilities(resp.ServerCapabilities)
+	return resp, nil
+}
+
+func (s *ProviderServer) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	return &tfprotov5.GetFunctionsResponse{
+		Functions: s.definitions(),
+	}, nil
+}
+
+func (s *ProviderServer) CallFunction(_ context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	function, ok := s.functions[req.Name]
+	if !ok {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				Text: "unknown function " + req.Name,
+			},
+		}, nil
+	}
+	result, funcErr := functions.Call(function, req.Arguments)
+	return &tfprotov5.CallFunctionResponse{
+		Result: result,
+		Error:  funcErr,
+	}, nil
+}
+
+// PlanResourceChange returns the diagnostics collected during the plan, because the CustomizeDiff of the plugin SDK can only return an error.
+// The error returned by the CustomizeDiff is replaced with the collected errors, so each of them is reported separately.
+func (s *ProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	ctx, collected := services.WithPlanDiagnostics(ctx)
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	resp.Diagnostics = appendPlanDiagnostics(resp.Diagnostics, collected())
+	return resp, nil
+}
+
+// appendPlanDiagnostics appends the collected diagnostics to the ones of the plugin SDK, and removes the placeholder error of the collected errors.
+func appendPlanDiagnostics(diags []*tfprotov5.Diagnostic, collected diag.Diagnostics) []*tfprotov5.Diagnostic {
+	if collected.HasError() {
+		filtered := make([]*tfprotov5.Diagnostic, 0, len(diags))
+		for _, d := range diags {
+			if d != nil && d.Severity == tfprotov5.DiagnosticSeverityError && d.Summary == services.ErrPlanDiagnostics.Error() {
+				continue
+			}
+			filtered = append(filtered, d)
+		}
+		diags = filtered
+	}
+	for _, d := range collected {
+		severity := tfprotov5.DiagnosticSeverityWarning
+		if d.Severity == diag.Error {
+			severity = tfprotov5.DiagnosticSeverityError
+		}
+		diags = append(diags, &tfprotov5.Diagnostic{
+			Severity:  severity,
+			Summary:   d.Summary,
+			Detail:    d.Detail,
+			Attribute: attributePath(d.AttributePath),
+		})
+	}
+	return diags
+}
+
+// MoveResourceState moves the state of an `azurerm_*` resource to `azapi_resource`. The resource is imported with its ARM id and read,
+// the same as `terraform import`, so the body only contains the writable properties returned by Azure.
+func (s *ProviderServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	if req.TargetTypeName != "azapi_resource" {
+		return s.ProviderServer.MoveResourceState(ctx, req)
+	}
+	resp := &tfprotov5.MoveResourceStateResponse{}
+	if req.SourceState == nil || len(req.SourceState.JSON) == 0 {
+		resp.Diagnostics = append(resp.Diagnostics, moveStateError(fmt.Sprintf("the state of %s is empty", req.SourceTypeName)))
+		return resp, nil
+	}
+	importId, err := services.MoveStateImportId(req.SourceProviderAddress, req.SourceTypeName, req.SourceState.JSON)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, moveStateError(err.Error()))
+		return resp, nil
+	}
+
+	importResp, err := s.ProviderServer.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: req.TargetTypeName,
+		ID:       importId,
+	})
+	if err != nil || importResp == nil {
+		return nil, err
+	}
+	resp.Diagnostics = append(resp.Diagnostics, importResp.Diagnostics...)
+	if hasError(resp.Diagnostics) {
+		return resp, nil
+	}
+	if len(importResp.ImportedResources) != 1 {
+		resp.Diagnostics = append(resp.Diagnostics, moveStateError(fmt.Sprintf("expect 1 imported resource for %s but got %d", importId, len(importResp.ImportedResources))))
+		return resp, nil
+	}
+
+	imported := importResp.ImportedResources[0]
+	readResp, err := s.ProviderServer.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     req.TargetTypeName,
+		CurrentState: imported.State,
+		Private:      imported.Private,
+	})
+	if err != nil || readResp == nil {
+		return nil, err
+	}
+	resp.Diagnostics = append(resp.Diagnostics, readResp.Diagnostics...)
+	if hasError(resp.Diagnostics) {
+		return resp, nil
+	}
+	// the state is null if the resource doesn't exist
+	notFound := readResp.NewState == nil
+	if !notFound {
+		notFound, _ = readResp.NewState.IsNull()
+	}
+	if notFound {
+		resp.Diagnostics = append(resp.Diagnostics, moveStateError(fmt.Sprintf("the azure resource %s can't be found", importId)))
+		return resp, nil
+	}
+	// the import id contains the api-version, which is kept in `type`, the moved resource's id is the same as its azure resource id
+	id, err := parse.ResourceID(importId)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, moveStateError(err.Error()))
+		return resp, nil
+	}
+	targetState, err := stateWithId(readResp.NewState, id.ID())
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, moveStateError(err.Error()))
+		return resp, nil
+	}
+	resp.TargetState = targetState
+	resp.TargetPrivate = readResp.Private
+	return resp, nil
+}
+
+// stateWithId returns the state of `azapi_resource` whose `id` is replaced.
+func stateWithId(state *tfprotov5.DynamicValue, id string) (*tfprotov5.DynamicValue, error) {
+	ty := services.ResourceAzureGenericResource().CoreConfigSchema().ImpliedType()
+	value, err := msgpack.Unmarshal(state.MsgPack, ty)
+	if err != nil {
+		return nil, err
+	}
+	attributes := value.AsValueMap()
+	attributes["id"] = cty.StringVal(id)
+	data, err := msgpack.Marshal(cty.ObjectVal(attributes), ty)
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov5.DynamicValue{MsgPack: data}, nil
+}
+
+// serverCapabilities enables the MoveResourceState, which isn't supported by the plugin SDK.
+func serverCapabilities(capabilities *tfprotov5.ServerCapabilities) *tfprotov5.ServerCapabilities {
+	if capabilities == nil {
+		capabilities = &tfprotov5.ServerCapabilities{}
+	}
+	capabilities.MoveResourceState = true
+	return capabilities
+}
+
+func moveStateError(detail string) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  "Unable to move the resource state to azapi_resource",
+		Detail:   detail,
+	}
+}
+
+func hasError(diags []*tfprotov5.Diagnostic) bool {
+	for _, d := range diags {
+		if d != nil && d.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// attributePath converts the path of a diagnostic to the path in the protocol, only the attribute names are supported.
+func attributePath(path cty.Path) *tftypes.AttributePath {
+	if len(path) == 0 {
+		return nil
+	}
+	res := tftypes.NewAttributePath()
+	for _, step := range path {
+		attr, ok := step.(cty.GetAttrStep)
+		if !ok {
+			return nil
+		}
+		res = res.WithAttributeName(attr.Name)
+	}
+	return res
+}
+
+func (s *ProviderServer) definitions() map[string]*tfprotov5.Function {
+	definitions := make(map[string]*tfprotov5.Function)
+	for name, function := range s.functions {
+		definitions[name] = function.Definition()
+	}
+	return definitions
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// maxCompatibilityErrors is the max number of the validation errors against the newer api-version in the warnings.
+const maxCompatibilityErrors = 3
+
+// apiVersionDiagnostics returns a warning if the api-version is a preview while a newer stable api-version exists, or it's outdated.
+// The api-version is outdated if it has `outdatedThreshold` or more newer stable api-versions, the previews are not counted,
+// because some services publish several previews between two stable api-versions.
+// The body is validated against the api-version to upgrade to, so the warning tells whether the upgrade is a drop-in change.
+func apiVersionDiagnostics(id parse.ResourceId, body interface{}, outdatedThreshold int) diag.Diagnostics {
+	// the api-versions are sorted, so the newer ones are in order
+	newer := make([]string, 0)
+	for _, apiVersion := range azure.GetApiVersions(id.AzureResourceType) {
+		if azure.CompareApiVersions(apiVersion, id.ApiVersion) > 0 {
+			newer = append(newer, apiVersion)
+		}
+	}
+	if len(newer) == 0 {
+		return nil
+	}
+	stableNewer := make([]string, 0)
+	for _, apiVersion := range newer {
+		if !azure.IsPreviewApiVersion(apiVersion) {
+			stableNewer = append(stableNewer, apiVersion)
+		}
+	}
+	if len(stableNewer) == 0 {
+		return nil
+	}
+	latestStable := stableNewer[len(stableNewer)-1]
+
+	var summary, detail, target string
+	switch {
+	case azure.IsPreviewApiVersion(id.ApiVersion):
+		summary = "Preview api-version"
+		detail = fmt.Sprintf("`%s@%s` is a preview api-version, and the newer stable api-version `%s` is available.", id.AzureResourceType, id.ApiVersion, latestStable)
+		target = latestStable
+	case len(stableNewer) >= outdatedThreshold:
+		summary = "Outdated api-version"
+		detail = fmt.Sprintf("`%s@%s` is %d stable api-versions behind the latest stable api-version `%s`.", id.AzureResourceType, id.ApiVersion, len(stableNewer), latestStable)
+		target = latestStable
+	default:
+		return nil
+	}
+	if compatibility := compatibilityDetail(id.AzureResourceType, target, body); compatibility != "" {
+		detail += " " + compatibility
+	}
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Warning,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: cty.GetAttrPath("type"),
+		},
+	}
+}
+
+// compatibilityDetail validates the body against the api-version, it returns whether the upgrade is a drop-in change.
+func compatibilityDetail(resourceType, apiVersion string, body interface{}) string {
+	definition, err := azure.GetResourceDefinition(resourceType, apiVersion)
+	if err != nil || definition == nil || body == nil {
+		return ""
+	}
+	messages := make([]string, 0)
+	for _, err := range definition.Validate(body, "") {
+		messages = append(messages, strings.TrimSpace(err.Error()))
+	}
+	if len(messages) == 0 {
+		return fmt.Sprintf("The body is valid for `%s`, upgrading to it is a drop-in change.", apiVersion)
+	}
+	sort.Strings(messages)
+	more := ""
+	if len(messages) > maxCompatibilityErrors {
+		more = fmt.Sprintf(", and %d more", len(messages)-maxCompatibilityErrors)
+		messages = messages[0:maxCompatibilityErrors]
+	}
+	return fmt.Sprintf("The body isn't valid for `%s`, upgrading to it requires changes: %s%s.", apiVersion, strings.Join(messages, "; "), more)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/features"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func Test_ApiVersionDiagnostics(t *testing.T) {
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Body         string
+		Threshold    int
+		Summary      string
+		Detail       string
+	}{
+		{
+			ResourceType: "Microsoft.Insights/dataCollectionRules",
+			ApiVersion:   "2019-11-01-preview",
+			Body:         `{"location": "westeurope", "kind": "Linux", "properties": {"description": "rule"}}`,
+			Summary:      "Preview api-version",
+			Detail:       "the newer stable api-version `2021-04-01` is available. The body is valid for `2021-04-01`, upgrading to it is a drop-in change.",
+		},
+		{
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			ApiVersion:   "2021-06-01-preview",
+			Body:         `{"location": "westeurope", "sku": {"name": "Standard", "tier": "Standard"}}`,
+			Summary:      "Preview api-version",
+			Detail:       "upgrading to it is a drop-in change.",
+		},
+		{
+			// there're 5 newer api-versions, but only 2 of them are stable
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			ApiVersion:   "2015-08-01",
+			Body:         `{"location": "westeurope", "properties": {"createACSNamespace": true}, "sku": {"name": "Standard", "tier": "Standard"}}`,
+		},
+		{
+			// the preview `2020-08-01-preview` is not counted
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			ApiVersion:   "2019-06-01",
+			Body:         `{"location": "westeurope", "kind": "StorageV2", "properties": {"supportsHttpsTrafficOnly": true}, "sku": {"name": "Standard_LRS"}}`,
+			Summary:      "Outdated api-version",
+			Detail:       "is 5 stable api-versions behind the latest stable api-version `2021-08-01`. The body is valid for `2021-08-01`, upgrading to it is a drop-in change.",
+		},
+		{
+			// 5 newer stable api-versions are below the threshold 6
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			ApiVersion:   "2019-06-01",
+			Body:         `{"location": "westeurope", "kind": "StorageV2", "properties": {"supportsHttpsTrafficOnly": true}, "sku": {"name": "Standard_LRS"}}`,
+			Threshold:    6,
+		},
+		{
+			// 2 newer stable api-versions reach the threshold 2
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			ApiVersion:   "2015-08-01",
+			Body:         `{"location": "westeurope", "properties": {"createACSNamespace": true}, "sku": {"name": "Standard", "tier": "Standard"}}`,
+			Threshold:    2,
+			Summary:      "Outdated api-version",
+			Detail:       "is 2 stable api-versions behind the latest stable api-version",
+		},
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			ApiVersion:   "2021-01-01",
+			Body:         `{"location": "westeurope", "kind": "StorageV2", "sku": {"name": "Standard_LRS"}}`,
+		},
+		{
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			ApiVersion:   "2021-11-01",
+			Body:         `{"location": "westeurope"}`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s with threshold %d", v.ResourceType, v.ApiVersion, v.Threshold)
+		id, err := parse.BuildResourceID("test", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg", v.ResourceType+"@"+v.ApiVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body interface{}
+		if err := json.Unmarshal([]byte(v.Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		threshold := v.Threshold
+		if threshold == 0 {
+			threshold = features.DefaultOutdatedApiVersionThreshold
+		}
+		diags := apiVersionDiagnostics(id, body, threshold)
+		if v.Summary == "" {
+			if len(diags) != 0 {
+				t.Fatalf("Expected no diagnostics but got %v", diags)
+			}
+			continue
+		}
+		if len(diags) != 1 || diags[0].Severity != diag.Warning || diags[0].Summary != v.Summary || !strings.Contains(diags[0].Detail, v.Detail) {
+			t.Fatalf("Expected a warning %q containing %q but got %v", v.Summary, v.Detail, diags)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/tf"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceAzureClientConfigDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: resourceAzureClientConfigDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subscription_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"client_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"environment": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"arm_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAzureClientConfigDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).AccountClient
+	ctx, cancel := tf.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	account, err := client.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("reading client config: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("clientConfigs/tenantId=%s;subscriptionId=%s;objectId=%s", account.TenantId, account.SubscriptionId, account.ObjectId))
+	d.Set("tenant_id", account.TenantId)
+	d.Set("subscription_id", account.SubscriptionId)
+	d.Set("client_id", account.ClientId)
+	d.Set("object_id", account.ObjectId)
+	d.Set("environment", account.Environment)
+	d.Set("arm_endpoint", account.ARMEndpoint)
+	return nil
+}
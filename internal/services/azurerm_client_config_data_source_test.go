@@ -0,0 +1,37 @@
+package services_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type ClientConfigDataSource struct{}
+
+func TestAccClientConfigDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_client_config", "test")
+	r := ClientConfigDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("tenant_id").HasValue(os.Getenv("ARM_TENANT_ID")),
+				check.That(data.ResourceName).Key("subscription_id").HasValue(os.Getenv("ARM_SUBSCRIPTION_ID")),
+				check.That(data.ResourceName).Key("client_id").HasValue(os.Getenv("ARM_CLIENT_ID")),
+				check.That(data.ResourceName).Key("object_id").Exists(),
+				check.That(data.ResourceName).Key("environment").HasValue("public"),
+				check.That(data.ResourceName).Key("arm_endpoint").HasValue("https://management.azure.com/"),
+			),
+		},
+	})
+}
+
+func (r ClientConfigDataSource) basic() string {
+	return `
+data "azapi_client_config" "test" {}
+`
+}
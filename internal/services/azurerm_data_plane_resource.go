@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/services/validate"
+	"github.com/Azure/terraform-provider-azapi/internal/tf"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceAzureDataPlaneResource() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAzureDataPlaneResourceCreateUpdate,
+		Read:   resourceAzureDataPlaneResourceRead,
+		Update: resourceAzureDataPlaneResourceCreateUpdate,
+		Delete: resourceAzureDataPlaneResourceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				id, err := parse.DataPlaneResourceID(d.Id(), meta.(*clients.Client).Environment)
+				if err != nil {
+					return nil, err
+				}
+				d.SetId(id.ID())
+				d.Set("type", fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"parent_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.AzureResourceID,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ResourceType,
+			},
+
+			// the body and the output may contain secrets, like the values of the key vault secrets
+			"body": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				Default:          "{}",
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: tf.SuppressJsonOrderingDifference,
+			},
+
+			"ignore_casing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"ignore_missing_property": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"response_export_values": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"output": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"create_headers": schemaRequestHeaders(),
+
+			"create_query_parameters": schemaRequestQueryParameters(),
+
+			"read_headers": schemaRequestHeaders(),
+
+			"read_query_parameters": schemaRequestQueryParameters(),
+
+			"update_headers": schemaRequestHeaders(),
+
+			"update_query_parameters": schemaRequestQueryParameters(),
+
+			"delete_headers": schemaRequestHeaders(),
+
+			"delete_query_parameters": schemaRequestQueryParameters(),
+		},
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.HasChange("response_export_values") {
+				d.SetNewComputed("output")
+			}
+			old, new := d.GetChange("body")
+			if utils.NormalizeJson(old) != utils.NormalizeJson(new) {
+				d.SetNewComputed("output")
+			}
+
+			// the api-version can be changed in place, but the resource type can't
+			if d.HasChange("type") {
+				oldType, newType := d.GetChange("type")
+				if oldType.(string) != "" && !isSameResourceType(oldType.(string), newType.(string)) {
+					if err := d.ForceNew("type"); err != nil {
+						return err
+					}
+				}
+			}
+
+			if d.NewValueKnown("name") && d.NewValueKnown("parent_id") && d.NewValueKnown("type") {
+				if _, err := parse.BuildDataPlaneResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string), meta.(*clients.Client).Environment); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func resourceAzureDataPlaneResourceCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !d.IsNewResource() && !hasUpdateChanges(d) {
+		// only the request options of the other requests are changed, the new values are kept in the state
+		return resourceAzureDataPlaneResourceRead(d, meta)
+	}
+
+	client := meta.(*clients.Client).DataPlaneClient
+	ctx, cancel := tf.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.BuildDataPlaneResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string), meta.(*clients.Client).Environment)
+	if err != nil {
+		return err
+	}
+	environment := meta.(*clients.Client).Environment
+	operation := operationUpdate
+	if d.IsNewResource() {
+		operation = operationCreate
+		_, _, err := client.Get(ctx, id.Url, dataPlaneRequestOption(d, id, environment, operationRead))
+		if err == nil {
+			return tf.ImportAsExistsError("azapi_data_plane_resource", fmt.Sprintf("%s?api-version=%s", id.ID(), id.ApiVersion))
+		}
+		if !utils.ResponseErrorWasNotFound(err) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+
+	var body interface{}
+	err = json.Unmarshal([]byte(d.Get("body").(string)), &body)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.CreateOrUpdate(ctx, id.Url, body, dataPlaneRequestOption(d, id, environment, operation))
+	if err != nil {
+		return fmt.Errorf("creating/updating %q: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return resourceAzureDataPlaneResourceRead(d, meta)
+}
+
+func resourceAzureDataPlaneResourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).DataPlaneClient
+	ctx, cancel := tf.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	_, apiVersion, _ := utils.SplitResourceType(d.Get("type").(string))
+	id, err := parse.DataPlaneResourceID(fmt.Sprintf("%s?api-version=%s", d.Id(), apiVersion), meta.(*clients.Client).Environment)
+	if err != nil {
+		return err
+	}
+
+	responseBody, _, err := client.Get(ctx, id.Url, dataPlaneRequestOption(d, id, meta.(*clients.Client).Environment, operationRead))
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			log.Printf("[INFO] Error reading %q - removing from state", id.ID())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("reading %q: %+v", id, err)
+	}
+
+	bodyJson := d.Get("body").(string)
+	var requestBody interface{}
+	err = json.Unmarshal([]byte(bodyJson), &requestBody)
+	if err != nil && len(bodyJson) != 0 {
+		return err
+	}
+
+	// the imported resource has no body in the state, the response body is used
+	if requestBody == nil {
+		requestBody = responseBody
+	}
+	option := utils.UpdateJsonOption{
+		IgnoreCasing:          d.Get("ignore_casing").(bool),
+		IgnoreMissingProperty: d.Get("ignore_missing_property").(bool),
+	}
+	data, err := json.Marshal(utils.GetUpdatedJson(requestBody, responseBody, option))
+	if err != nil {
+		return err
+	}
+	d.Set("body", string(data))
+
+	d.Set("name", id.Name)
+	d.Set("parent_id", id.ParentId)
+	d.Set("type", fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion))
+	d.Set("output", flattenOutput(responseBody, d.Get("response_export_values").([]interface{})))
+	return nil
+}
+
+func resourceAzureDataPlaneResourceDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).DataPlaneClient
+	ctx, cancel := tf.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.BuildDataPlaneResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string), meta.(*clients.Client).Environment)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.Delete(ctx, id.Url, dataPlaneRequestOption(d, id, meta.(*clients.Client).Environment, operationDelete))
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting %q: %+v", id, err)
+	}
+
+	return nil
+}
+
+// dataPlaneRequestOption builds the token scope, headers and query parameters from the data-plane resource type's definition,
+// and the extra headers and query parameters of the operation. The ones from the definition take precedence over the extra ones.
+func dataPlaneRequestOption(d resourceGetter, id parse.DataPlaneResourceId, environment string, operation string) clients.DataPlaneRequestOption {
+	extra := requestOption(d, operation)
+	option := clients.DataPlaneRequestOption{
+		Scope:           id.ResourceDef.Scopes[environment],
+		Headers:         extra.Headers,
+		QueryParameters: extra.QueryParameters,
+	}
+	for key, value := range id.ResourceDef.Headers {
+		option.Headers[key] = value
+	}
+	if operation == operationDelete {
+		for key, value := range id.ResourceDef.DeleteHeaders {
+			option.Headers[key] = value
+		}
+	}
+	if id.ResourceDef.ApiVersionHeader != "" {
+		option.Headers[id.ResourceDef.ApiVersionHeader] = id.ApiVersion
+	} else {
+		option.QueryParameters["api-version"] = id.ApiVersion
+	}
+	return option
+}
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type DataPlaneResource struct{}
+
+func TestAccDataPlaneResource_appConfigurationKeyValue(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource", "test")
+	r := DataPlaneResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.appConfigurationKeyValue(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("output").HasValue(`{"value":"value1"}`),
+			),
+		},
+		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
+	})
+}
+
+func TestAccDataPlaneResource_keyVaultSecret(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource", "test")
+	r := DataPlaneResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.keyVaultSecret(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func (DataPlaneResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	id, err := parse.BuildDataPlaneResourceID(state.Attributes["name"], state.Attributes["parent_id"], state.Attributes["type"], client.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	option := clients.DataPlaneRequestOption{
+		Scope:           id.ResourceDef.Scopes[client.Environment],
+		Headers:         id.ResourceDef.Headers,
+		QueryParameters: map[string]string{},
+	}
+	if id.ResourceDef.ApiVersionHeader == "" {
+		option.QueryParameters["api-version"] = id.ApiVersion
+	}
+	_, _, err = client.DataPlaneClient.Get(ctx, id.Url, option)
+	if err == nil {
+		b := true
+		return &b, nil
+	}
+	if utils.ResponseErrorWasNotFound(err) {
+		b := false
+		return &b, nil
+	}
+	return nil, fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+}
+
+func (DataPlaneResource) ImportIdFunc(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["azapi_data_plane_resource.test"].Primary
+	id, err := parse.BuildDataPlaneResourceID(state.Attributes["name"], state.Attributes["parent_id"], state.Attributes["type"], "public")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s?api-version=%s", id.AzureResourceId, id.ApiVersion), nil
+}
+
+func (DataPlaneResource) importStateCheckFunc(states []*terraform.InstanceState) error {
+	if len(states) != 1 {
+		return fmt.Errorf("expect states length is 1, but got %d", len(states))
+	}
+	state := states[0]
+	props := []string{"name", "parent_id", "type", "id", "body"}
+	for _, prop := range props {
+		if len(state.Attributes[prop]) == 0 {
+			return fmt.Errorf("expect `%s` is not empty", prop)
+		}
+	}
+	return nil
+}
+
+func (r DataPlaneResource) appConfigurationKeyValue(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_app_configuration" "test" {
+  name                = "acctest-appconf%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "standard"
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_app_configuration.test.id
+  role_definition_name = "App Configuration Data Owner"
+  principal_id         = data.azapi_client_config.current.object_id
+}
+
+resource "azapi_data_plane_resource" "test" {
+  name      = "key1"
+  parent_id = azurerm_app_configuration.test.id
+  type      = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  body      = <<BODY
+{
+  "value": "value1",
+  "content_type": "text/plain"
+}
+BODY
+
+  response_export_values = ["value"]
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r DataPlaneResource) keyVaultSecret(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault" "test" {
+  name                = "acctestkv%[2]s"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  tenant_id           = data.azapi_client_config.current.tenant_id
+  sku_name            = "standard"
+
+  access_policy {
+    tenant_id          = data.azapi_client_config.current.tenant_id
+    object_id          = data.azapi_client_config.current.object_id
+    secret_permissions = ["Get", "Set", "Delete", "Purge"]
+  }
+}
+
+resource "azapi_data_plane_resource" "test" {
+  name      = "secret1"
+  parent_id = azurerm_key_vault.test.id
+  type      = "Microsoft.KeyVault/vaults/secrets@7.4"
+  body      = <<BODY
+{
+  "value": "secret-value",
+  "contentType": "text/plain"
+}
+BODY
+}
+`, r.template(data), data.RandomString)
+}
+
+func (DataPlaneResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azapi_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+`, data.RandomInteger, data.LocationPrimary)
+}
@@ -1,7 +1,6 @@
 package services
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -34,7 +33,8 @@ func ResourceAzureGenericDataSource() *schema.Resource {
 
 			"parent_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				//ValidateFunc: validate.AzureResourceID,
 			},
 
@@ -62,6 +62,10 @@ func ResourceAzureGenericDataSource() *schema.Resource {
 			},
 
 			"tags": tags.SchemaTagsDataSource(),
+
+			"read_headers": schemaRequestHeaders(),
+
+			"read_query_parameters": schemaRequestQueryParameters(),
 		},
 	}
 }
@@ -71,12 +75,18 @@ func resourceAzureGenericDataSourceRead(d *schema.ResourceData, meta interface{}
 	ctx, cancel := tf.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	id, err := parse.BuildResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string))
+	var id parse.ResourceId
+	var err error
+	if isConfigExist(d.GetRawConfig(), "parent_id") {
+		id, err = parse.BuildResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string))
+	} else {
+		id, err = parse.BuildResourceIDWithDefaultParent(d.Get("name").(string), defaultParent(meta.(*clients.Client)), d.Get("type").(string))
+	}
 	if err != nil {
 		return err
 	}
 
-	responseBody, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	responseBody, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, requestOption(d, operationRead))
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			return fmt.Errorf("not found %q: %+v", id, err)
@@ -92,22 +102,6 @@ func resourceAzureGenericDataSourceRead(d *schema.ResourceData, meta interface{}
 		d.Set("identity", identity.FlattenIdentity(bodyMap["identity"]))
 	}
 
-	paths := d.Get("response_export_values").([]interface{})
-	var output interface{}
-	if len(paths) != 0 {
-		output = make(map[string]interface{})
-		for _, path := range paths {
-			part := utils.ExtractObject(responseBody, path.(string))
-			if part == nil {
-				continue
-			}
-			output = utils.GetMergedJson(output, part)
-		}
-	}
-	if output == nil {
-		output = make(map[string]interface{})
-	}
-	outputJson, _ := json.Marshal(output)
-	d.Set("output", string(outputJson))
+	d.Set("output", flattenOutput(responseBody, d.Get("response_export_values").([]interface{})))
 	return nil
 }
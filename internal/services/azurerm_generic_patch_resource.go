@@ -104,6 +104,14 @@ func ResourceAzureGenericPatchResource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"read_headers": schemaRequestHeaders(),
+
+			"read_query_parameters": schemaRequestQueryParameters(),
+
+			"update_headers": schemaRequestHeaders(),
+
+			"update_query_parameters": schemaRequestQueryParameters(),
 		},
 
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
@@ -128,6 +136,11 @@ func ResourceAzureGenericPatchResource() *schema.Resource {
 }
 
 func resourceAzureGenericPatchResourceCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !d.IsNewResource() && !hasUpdateChanges(d) {
+		// only the request options of the other requests are changed, the new values are kept in the state
+		return resourceAzureGenericPatchResourceRead(d, meta)
+	}
+
 	client := meta.(*clients.Client).ResourceClient
 	ctx, cancel := tf.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -146,8 +159,11 @@ func resourceAzureGenericPatchResourceCreateUpdate(d *schema.ResourceData, meta
 		}
 		id = buildId
 	}
+	if err := id.CheckWritableScope(); err != nil {
+		return err
+	}
 
-	existing, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	existing, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, requestOption(d, operationRead))
 	if err != nil {
 		return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
 	}
@@ -167,7 +183,7 @@ func resourceAzureGenericPatchResourceCreateUpdate(d *schema.ResourceData, meta
 	}
 	j, _ := json.Marshal(requestBody)
 	log.Printf("[INFO] request body: %v\n", string(j))
-	_, _, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, requestBody)
+	_, _, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, requestBody, requestOption(d, operationUpdate))
 	if err != nil {
 		return fmt.Errorf("creating/updating %q: %+v", id, err)
 	}
@@ -193,7 +209,7 @@ func resourceAzureGenericPatchResourceRead(d *schema.ResourceData, meta interfac
 		return err
 	}
 
-	responseBody, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	responseBody, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, requestOption(d, operationRead))
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			log.Printf("[INFO] Error reading %q - removing from state", d.Id())
@@ -225,23 +241,7 @@ func resourceAzureGenericPatchResourceRead(d *schema.ResourceData, meta interfac
 	}
 	d.Set("body", string(data))
 
-	paths := d.Get("response_export_values").([]interface{})
-	var output interface{}
-	if len(paths) != 0 {
-		output = make(map[string]interface{})
-		for _, path := range paths {
-			part := utils.ExtractObject(responseBody, path.(string))
-			if part == nil {
-				continue
-			}
-			output = utils.GetMergedJson(output, part)
-		}
-	}
-	if output == nil {
-		output = make(map[string]interface{})
-	}
-	outputJson, _ := json.Marshal(output)
-	d.Set("output", string(outputJson))
+	d.Set("output", flattenOutput(responseBody, d.Get("response_export_values").([]interface{})))
 	return nil
 }
 
@@ -57,7 +57,7 @@ func (r GenericPatchResource) Exists(ctx context.Context, client *clients.Client
 		return nil, err
 	}
 
-	resp, _, err := client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	resp, _, err := client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOption{})
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			exist := false
@@ -6,27 +6,30 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure/identity"
 	"github.com/Azure/terraform-provider-azapi/internal/azure/location"
 	"github.com/Azure/terraform-provider-azapi/internal/azure/tags"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/features"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/Azure/terraform-provider-azapi/internal/services/validate"
 	"github.com/Azure/terraform-provider-azapi/internal/tf"
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceAzureGenericResource() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAzureGenericResourceCreateUpdate,
-		Read:   resourceAzureGenericResourceRead,
-		Update: resourceAzureGenericResourceCreateUpdate,
-		Delete: resourceAzureGenericResourceDelete,
+		CreateContext: resourceAzureGenericResourceCreateUpdate,
+		Read:          resourceAzureGenericResourceRead,
+		UpdateContext: resourceAzureGenericResourceCreateUpdate,
+		Delete:        resourceAzureGenericResourceDelete,
 
 		Importer: tf.DefaultImporter(func(id string) error {
 			_, err := parse.ResourceID(id)
@@ -50,7 +53,8 @@ func ResourceAzureGenericResource() *schema.Resource {
 
 			"parent_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 				//ValidateFunc: validate.AzureResourceID,
 			},
@@ -104,7 +108,30 @@ func ResourceAzureGenericResource() *schema.Resource {
 				Computed: true,
 			},
 
+			"rendered_body": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"tags": tags.SchemaTagsOC(),
+
+			"tags_all": tags.SchemaTagsDataSource(),
+
+			"create_headers": schemaRequestHeaders(),
+
+			"create_query_parameters": schemaRequestQueryParameters(),
+
+			"read_headers": schemaRequestHeaders(),
+
+			"read_query_parameters": schemaRequestQueryParameters(),
+
+			"update_headers": schemaRequestHeaders(),
+
+			"update_query_parameters": schemaRequestQueryParameters(),
+
+			"delete_headers": schemaRequestHeaders(),
+
+			"delete_query_parameters": schemaRequestQueryParameters(),
 		},
 
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
@@ -116,161 +143,205 @@ func ResourceAzureGenericResource() *schema.Resource {
 				d.SetNewComputed("output")
 			}
 
-			id, err := parse.BuildResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string))
-			if err != nil && len(id.ParentId) > 0 {
-				return err
+			// the api-version can be changed in place, but the resource type can't. If the new `type` is unknown, the resource type
+			// may be changed, so the resource is replaced.
+			if d.HasChange("type") {
+				oldType, newType := d.GetChange("type")
+				if oldType.(string) != "" && (!d.NewValueKnown("type") || !isSameResourceType(oldType.(string), newType.(string))) {
+					if err := d.ForceNew("type"); err != nil {
+						return err
+					}
+				}
+				d.SetNewComputed("output")
 			}
 
-			// body refers other resource, can't be verified during plan
-			if len(d.Get("body").(string)) == 0 {
+			config := d.GetRawConfig()
+			var id parse.ResourceId
+			var err error
+			if isConfigExist(config, "parent_id") {
+				id, err = parse.BuildResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string))
+				if err != nil && len(id.ParentId) > 0 {
+					return err
+				}
+			} else if d.NewValueKnown("type") {
+				id, err = parse.BuildResourceIDWithDefaultParent(d.Get("name").(string), defaultParent(meta.(*clients.Client)), d.Get("type").(string))
+				if err != nil {
+					return err
+				}
+				if d.Get("parent_id").(string) != id.ParentId {
+					if err := d.SetNew("parent_id", id.ParentId); err != nil {
+						return err
+					}
+				}
+			}
+
+			if id.AzureResourceId != "" {
+				if err := id.CheckWritableScope(); err != nil {
+					return err
+				}
+			}
+
+			// body refers other resource, or the resource type is unknown, can't be verified during plan
+			if len(d.Get("body").(string)) == 0 || !d.NewValueKnown("type") {
+				d.SetNewComputed("tags_all")
+				d.SetNewComputed("rendered_body")
 				return nil
 			}
 
-			var body map[string]interface{}
-			err = json.Unmarshal([]byte(d.Get("body").(string)), &body)
+			input := newRequestBody(d, meta.(*clients.Client).Features)
+			// the tags and the identity ids may refer other resources, they can't be merged during plan
+			if !isConfigKnown(config, "tags") {
+				input.Tags = nil
+			}
+			if !isConfigKnown(config, "identity") {
+				input.Identity = nil
+			}
+			rendered, err := renderRequestBody(id, input)
 			if err != nil {
 				return err
 			}
+			body := rendered.Body
 
-			props := []string{"identity", "location", "tags"}
-			config := d.GetRawConfig()
-			for _, prop := range props {
-				if isConfigExist(config, prop) && body[prop] != nil {
-					return fmt.Errorf("can't specify both property `%[1]s` and `%[1]s` in `body`", prop)
-				}
+			if !d.NewValueKnown("tags") {
+				d.SetNewComputed("tags_all")
+			} else if !reflect.DeepEqual(tags.ExpandTags(d.Get("tags_all").(map[string]interface{})), rendered.TagsAll) {
+				d.SetNew("tags_all", rendered.TagsAll)
 			}
 
-			if !isConfigExist(config, "tags") && body["tags"] == nil && len(meta.(*clients.Client).Features.DefaultTags) != 0 {
-				if isResourceHasProperty(id.ResourceDef, "tags") {
-					body["tags"] = meta.(*clients.Client).Features.DefaultTags
-					currentTags := d.Get("tags")
-					defaultTags := meta.(*clients.Client).Features.DefaultTags
-					if !reflect.DeepEqual(currentTags, defaultTags) {
-						d.SetNew("tags", defaultTags)
-					}
-				}
+			if rendered.DefaultLocation != "" && location.Normalize(d.Get("location").(string)) != location.Normalize(rendered.DefaultLocation) {
+				d.SetNew("location", rendered.DefaultLocation)
 			}
 
-			if !isConfigExist(config, "location") && body["location"] == nil && len(meta.(*clients.Client).Features.DefaultLocation) != 0 {
-				if isResourceHasProperty(id.ResourceDef, "location") {
-					body["location"] = meta.(*clients.Client).Features.DefaultLocation
-					currentLocation := d.Get("location").(string)
-					defaultLocation := meta.(*clients.Client).Features.DefaultLocation
-					if location.Normalize(currentLocation) != location.Normalize(defaultLocation) {
-						d.SetNew("location", defaultLocation)
+			// the rendered body is only updated when the resource is created or updated, because it's the body sent to Azure.
+			// The changed keys don't include the ones set in this function, so the computed tags and location are checked separately.
+			// the remote values of the ignored tags are only known when the existing resource is updated
+			switch {
+			case !d.NewValueKnown("type") || !isConfigKnown(config, "tags", "location", "identity"):
+				d.SetNewComputed("rendered_body")
+			case d.Id() != "" && hasIgnoreTags(meta.(*clients.Client).Features) && isResourceHasProperty(id.ResourceDef, "tags"):
+				if hasUpdateChangedKeys(d) || d.HasChange("tags_all") || d.HasChange("location") {
+					d.SetNewComputed("rendered_body")
+				}
+			case d.Id() == "" || hasUpdateChangedKeys(d) || d.HasChange("tags_all") || d.HasChange("location"):
+				renderedBody, err := json.Marshal(body)
+				if err != nil {
+					return err
+				}
+				if d.Get("rendered_body").(string) != string(renderedBody) {
+					if err := d.SetNew("rendered_body", string(renderedBody)); err != nil {
+						return err
 					}
 				}
 			}
 
 			if d.Get("schema_validation_enabled").(bool) {
-				if value, ok := d.GetOk("tags"); ok && isConfigExist(config, "tags") {
-					tagsModel := tags.ExpandTags(value.(map[string]interface{}))
-					if len(tagsModel) != 0 {
-						body["tags"] = tagsModel
-					}
-				}
-				if value, ok := d.GetOk("location"); ok {
-					body["location"] = location.Normalize(value.(string))
-				}
-				if value, ok := d.GetOk("identity"); ok {
-					identityModel, err := identity.ExpandIdentity(value.([]interface{}))
-					if err != nil {
-						return err
-					}
-					if identityModel != nil {
-						body["identity"] = identityModel
-					}
+				diags := schemaValidation(id, body, meta.(*clients.Client).Features.Rules, meta.(*clients.Client).Features.OutdatedApiVersionThreshold)
+				if err := planDiagnosticsError(ctx, diags); err != nil {
+					return err
 				}
-				if err := schemaValidation(id, body); err != nil {
+				addPlanWarnings(ctx, diags)
+			}
+
+			// the policies are evaluated when the expanded body is known, otherwise they're evaluated before the resource is created or updated
+			if isConfigKnown(config, "tags", "location", "identity") {
+				diags := policyDiagnostics(meta.(*clients.Client).Features.Policies, id, body)
+				if err := planDiagnosticsError(ctx, diags); err != nil {
 					return err
 				}
+				addPlanWarnings(ctx, diags)
 			}
 			return nil
 		},
 	}
 }
 
-func resourceAzureGenericResourceCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+// resourceAzureGenericResourceCreateUpdate returns the diagnostics, so each error of the schema validation is reported separately.
+func resourceAzureGenericResourceCreateUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.IsNewResource() && !hasUpdateChanges(d) {
+		// only the request options of the other requests are changed, the new values are kept in the state
+		return diag.FromErr(resourceAzureGenericResourceRead(d, meta))
+	}
+
 	client := meta.(*clients.Client).ResourceClient
 	ctx, cancel := tf.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	id, err := parse.BuildResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string))
+	config := d.GetRawConfig()
+	var id parse.ResourceId
+	var err error
+	if isConfigExist(config, "parent_id") {
+		id, err = parse.BuildResourceID(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string))
+	} else {
+		id, err = parse.BuildResourceIDWithDefaultParent(d.Get("name").(string), defaultParent(meta.(*clients.Client)), d.Get("type").(string))
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := id.CheckWritableScope(); err != nil {
+		return diag.FromErr(err)
 	}
 
+	operation := operationUpdate
 	if d.IsNewResource() {
-		_, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+		operation = operationCreate
+		_, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, requestOption(d, operationRead))
 		if err == nil {
-			return tf.ImportAsExistsError("azapi_resource", id.ID())
+			return diag.FromErr(tf.ImportAsExistsError("azapi_resource", id.ID()))
 		}
 		if !utils.ResponseErrorWasNotFound(err) {
-			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			return diag.Errorf("checking for presence of existing %s: %+v", id, err)
 		}
 	}
 
-	var body map[string]interface{}
-	err = json.Unmarshal([]byte(d.Get("body").(string)), &body)
-	if err != nil {
-		return err
-	}
-
-	props := []string{"identity", "location", "tags"}
-	config := d.GetRawConfig()
-	for _, prop := range props {
-		if isConfigExist(config, prop) && body[prop] != nil {
-			return fmt.Errorf("can't specify both property `%[1]s` and `%[1]s` in `body`", prop)
+	features := meta.(*clients.Client).Features
+	input := newRequestBody(d, features)
+	// the ignored tags are managed outside of Terraform, their remote values are kept in the request body
+	if !d.IsNewResource() && hasIgnoreTags(features) && isResourceHasProperty(id.ResourceDef, "tags") {
+		existing, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, requestOption(d, operationRead))
+		if err != nil {
+			return diag.Errorf("reading %q: %+v", id, err)
 		}
-	}
-
-	if !isConfigExist(config, "tags") && body["tags"] == nil && len(meta.(*clients.Client).Features.DefaultTags) != 0 {
-		if isResourceHasProperty(id.ResourceDef, "tags") {
-			body["tags"] = meta.(*clients.Client).Features.DefaultTags
+		if bodyMap, ok := existing.(map[string]interface{}); ok {
+			if remoteTags, ok := bodyMap["tags"].(map[string]interface{}); ok {
+				input.RemoteTags = remoteTags
+			}
 		}
 	}
+	rendered, err := renderRequestBody(id, input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	body := rendered.Body
 
-	if !isConfigExist(config, "location") && body["location"] == nil && len(meta.(*clients.Client).Features.DefaultLocation) != 0 {
-		if isResourceHasProperty(id.ResourceDef, "location") {
-			body["location"] = meta.(*clients.Client).Features.DefaultLocation
+	if d.Get("schema_validation_enabled").(bool) {
+		if diags := schemaValidation(id, body, meta.(*clients.Client).Features.Rules, meta.(*clients.Client).Features.OutdatedApiVersionThreshold); diags.HasError() {
+			return diags
 		}
 	}
 
-	if value, ok := d.GetOk("tags"); ok && isConfigExist(config, "tags") {
-		tagsModel := tags.ExpandTags(value.(map[string]interface{}))
-		if len(tagsModel) != 0 {
-			body["tags"] = tagsModel
-		}
+	policyDiags := policyDiagnostics(meta.(*clients.Client).Features.Policies, id, body)
+	if policyDiags.HasError() {
+		return policyDiags
 	}
-	if value, ok := d.GetOk("location"); ok {
-		body["location"] = location.Normalize(value.(string))
-	}
-	if value, ok := d.GetOk("identity"); ok {
-		identityModel, err := identity.ExpandIdentity(value.([]interface{}))
-		if err != nil {
-			return err
-		}
-		if identityModel != nil {
-			body["identity"] = identityModel
-		}
+	// the warnings have been reported if the policies were evaluated during plan
+	if isPlanKnown(d, "type", "body", "tags", "location", "identity") {
+		policyDiags = nil
 	}
 
-	if d.Get("schema_validation_enabled").(bool) {
-		if err := schemaValidation(id, body); err != nil {
-			return err
-		}
+	renderedBody, err := json.Marshal(body)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-
-	j, _ := json.Marshal(body)
-	log.Printf("[INFO] request body: %v\n", string(j))
-	_, _, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body)
+	log.Printf("[INFO] request body: %v\n", string(renderedBody))
+	_, _, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body, requestOption(d, operation))
 	if err != nil {
-		return fmt.Errorf("creating/updating %q: %+v", id, err)
+		return diag.Errorf("creating/updating %q: %+v", id, err)
 	}
 
 	d.SetId(id.ID())
+	d.Set("rendered_body", string(renderedBody))
 
-	return resourceAzureGenericResourceRead(d, meta)
+	return append(policyDiags, diag.FromErr(resourceAzureGenericResourceRead(d, meta))...)
 }
 
 func resourceAzureGenericResourceRead(d *schema.ResourceData, meta interface{}) error {
@@ -289,7 +360,7 @@ func resourceAzureGenericResourceRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	responseBody, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	responseBody, _, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, requestOption(d, operationRead))
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			log.Printf("[INFO] Error reading %q - removing from state", id.ID())
@@ -331,28 +402,26 @@ func resourceAzureGenericResourceRead(d *schema.ResourceData, meta interface{})
 	d.Set("type", fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion))
 
 	if bodyMap, ok := responseBody.(map[string]interface{}); ok {
-		d.Set("tags", tags.FlattenTags(bodyMap["tags"]))
+		features := meta.(*clients.Client).Features
+		tagsAll := tags.IgnoreTags(tags.FlattenTags(bodyMap["tags"]), features.IgnoreTagKeys, features.IgnoreTagKeyPrefixes)
+		configuredTags := d.Get("tags").(map[string]interface{})
+		resourceTags := tags.RemoveDefaultTags(tagsAll, features.DefaultTags, configuredTags)
+		// the ignored tags in the config are kept, they're not in `tags_all` so they don't cause diffs
+		for key, value := range configuredTags {
+			if tags.IsIgnoredTag(key, features.IgnoreTagKeys, features.IgnoreTagKeyPrefixes) {
+				if resourceTags == nil {
+					resourceTags = make(map[string]interface{})
+				}
+				resourceTags[key] = value
+			}
+		}
+		d.Set("tags", resourceTags)
+		d.Set("tags_all", tagsAll)
 		d.Set("location", bodyMap["location"])
 		d.Set("identity", identity.FlattenIdentity(bodyMap["identity"]))
 	}
 
-	paths := d.Get("response_export_values").([]interface{})
-	var output interface{}
-	if len(paths) != 0 {
-		output = make(map[string]interface{})
-		for _, path := range paths {
-			part := utils.ExtractObject(responseBody, path.(string))
-			if part == nil {
-				continue
-			}
-			output = utils.GetMergedJson(output, part)
-		}
-	}
-	if output == nil {
-		output = make(map[string]interface{})
-	}
-	outputJson, _ := json.Marshal(output)
-	d.Set("output", string(outputJson))
+	d.Set("output", flattenOutput(responseBody, d.Get("response_export_values").([]interface{})))
 	return nil
 }
 
@@ -372,7 +441,7 @@ func resourceAzureGenericResourceDelete(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	_, _, err = client.Delete(ctx, id.AzureResourceId, id.ApiVersion)
+	_, _, err = client.Delete(ctx, id.AzureResourceId, id.ApiVersion, requestOption(d, operationDelete))
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			return nil
@@ -383,6 +452,58 @@ func resourceAzureGenericResourceDelete(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
+func hasIgnoreTags(features features.UserFeatures) bool {
+	return len(features.IgnoreTagKeys) != 0 || len(features.IgnoreTagKeyPrefixes) != 0
+}
+
+// isConfigKnown returns whether the attributes in the config are wholly known.
+func isConfigKnown(config cty.Value, paths ...string) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	for _, path := range paths {
+		if config.Type().IsObjectType() && config.Type().HasAttribute(path) && !config.GetAttr(path).IsWhollyKnown() {
+			return false
+		}
+	}
+	return true
+}
+
+// isPlanKnown returns whether the configured values of the attributes were known during plan, it's used during apply when the config is wholly known.
+func isPlanKnown(d *schema.ResourceData, paths ...string) bool {
+	config, plan := d.GetRawConfig(), d.GetRawPlan()
+	if config.IsNull() || plan.IsNull() || !plan.IsKnown() {
+		return false
+	}
+	for _, path := range paths {
+		if !config.Type().HasAttribute(path) {
+			continue
+		}
+		known := true
+		_ = cty.Walk(config.GetAttr(path), func(valuePath cty.Path, value cty.Value) (bool, error) {
+			if value.IsNull() || !value.Type().IsPrimitiveType() {
+				return true, nil
+			}
+			planned, err := append(cty.GetAttrPath(path), valuePath...).Apply(plan)
+			if err != nil || !planned.IsKnown() {
+				known = false
+			}
+			return known, nil
+		})
+		if !known {
+			return false
+		}
+	}
+	return true
+}
+
+// isSameResourceType returns whether the types in a format like `<resource-type>@<api-version>` have the same resource type.
+func isSameResourceType(a, b string) bool {
+	resourceTypeA, _, _ := utils.SplitResourceType(a)
+	resourceTypeB, _, _ := utils.SplitResourceType(b)
+	return strings.EqualFold(resourceTypeA, resourceTypeB)
+}
+
 func isConfigExist(config cty.Value, path string) bool {
 	if config.CanIterateElements() {
 		configMap := config.AsValueMap()
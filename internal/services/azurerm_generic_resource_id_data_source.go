@@ -0,0 +1,163 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceAzureGenericResourceIdDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: resourceAzureGenericResourceIdDataSourceRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"resource_id", "name"},
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"resource_id", "name"},
+				RequiredWith: []string{"type"},
+			},
+
+			"parent_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"resource_id"},
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"scope_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subscription_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resource_group_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"provider_namespace": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"parts": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceAzureGenericResourceIdDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	var id parse.ResourceId
+	var err error
+	if _, ok := d.GetOk("resource_id"); ok {
+		id, err = resourceIdDataSourceParse(d.Get("resource_id").(string), d.Get("type").(string))
+	} else {
+		id, err = resourceIdDataSourceBuild(d.Get("name").(string), d.Get("parent_id").(string), d.Get("type").(string), defaultParent(meta.(*clients.Client)))
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id.ID())
+	d.Set("resource_id", id.AzureResourceId)
+	d.Set("name", id.Name)
+	d.Set("parent_id", id.ParentId)
+	if id.ApiVersion != "" {
+		d.Set("type", fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion))
+	} else {
+		d.Set("type", id.AzureResourceType)
+	}
+	d.Set("resource_type", id.AzureResourceType)
+	d.Set("scope_type", id.ScopeType().String())
+	d.Set("subscription_id", utils.GetSubscriptionId(id.AzureResourceId))
+	d.Set("resource_group_name", utils.GetResourceGroupName(id.AzureResourceId))
+	d.Set("provider_namespace", utils.GetProviderNamespace(id.AzureResourceType))
+	d.Set("parts", utils.GetIdParts(id.AzureResourceId))
+	return nil
+}
+
+// resourceIdDataSourceBuild builds the resource id, the default parent is used if the parent id is empty.
+func resourceIdDataSourceBuild(name, parentId, resourceType string, defaultParent parse.DefaultParent) (parse.ResourceId, error) {
+	resourceType, err := parse.ResourceTypeWithApiVersion(resourceType)
+	if err != nil {
+		return parse.ResourceId{}, err
+	}
+	if parentId == "" {
+		return parse.BuildResourceIDWithDefaultParent(name, defaultParent, resourceType)
+	}
+	return parse.BuildResourceID(name, parentId, resourceType)
+}
+
+// resourceIdDataSourceParse parses the resource id and checks it with the resource type's scope, the resource type is the one in the id if `type` is empty.
+func resourceIdDataSourceParse(azureResourceId, resourceType string) (parse.ResourceId, error) {
+	azureResourceType := utils.GetResourceType(azureResourceId)
+	if azureResourceType == "" {
+		return parse.ResourceId{}, fmt.Errorf("`resource_id` %q is invalid", azureResourceId)
+	}
+	if resourceType == "" {
+		resourceType = azureResourceType
+	}
+	if expected, _, _ := utils.SplitResourceType(resourceType); !strings.EqualFold(expected, azureResourceType) {
+		return parse.ResourceId{}, fmt.Errorf("`resource_id` is invalid, expect id of `%s`, but got id of `%s`", expected, azureResourceType)
+	}
+
+	// the tenant and subscription ids can't be built from the parent id, so there's no scope to check
+	if azureResourceType == "Tenant" || azureResourceType == "Subscription" {
+		return parse.ResourceId{
+			AzureResourceId:   azureResourceId,
+			AzureResourceType: azureResourceType,
+			Name:              utils.GetName(azureResourceId),
+			ParentId:          utils.GetParentId(azureResourceId),
+		}, nil
+	}
+
+	resourceType, err := parse.ResourceTypeWithApiVersion(resourceType)
+	if err != nil {
+		return parse.ResourceId{}, err
+	}
+	id, err := parse.NewResourceID(azureResourceId, resourceType)
+	if err != nil {
+		return parse.ResourceId{}, err
+	}
+	if !strings.EqualFold(id.AzureResourceId, azureResourceId) {
+		return parse.ResourceId{}, fmt.Errorf("`resource_id` is invalid, expect %q", id.AzureResourceId)
+	}
+	return id, nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/features"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_ResourceIdDataSourceRead(t *testing.T) {
+	testData := []struct {
+		Config   map[string]interface{}
+		Expected map[string]string
+		Error    string
+	}{
+		{
+			Config: map[string]interface{}{
+				"resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG/providers/Microsoft.Network/virtualNetworks/myVnet/subnets/mySubnet",
+			},
+			Expected: map[string]string{
+				"name":                  "mySubnet",
+				"parent_id":             "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG/providers/Microsoft.Network/virtualNetworks/myVnet",
+				"resource_type":         "Microsoft.Network/virtualNetworks/subnets",
+				"scope_type":            "ResourceGroup",
+				"subscription_id":       "00000000-0000-0000-0000-000000000000",
+				"resource_group_name":   "myRG",
+				"provider_namespace":    "Microsoft.Network",
+				"parts.virtualNetworks": "myVnet",
+				"parts.subnets":         "mySubnet",
+			},
+		},
+		{
+			Config: map[string]interface{}{
+				"name":      "myLock",
+				"parent_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG",
+				"type":      "Microsoft.Authorization/locks@2016-09-01",
+			},
+			Expected: map[string]string{
+				"resource_id":        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG/providers/Microsoft.Authorization/locks/myLock",
+				"scope_type":         "ResourceGroup",
+				"provider_namespace": "Microsoft.Authorization",
+			},
+		},
+		{
+			Config: map[string]interface{}{
+				"name":      "myLock",
+				"parent_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG/providers/Microsoft.Network/virtualNetworks/myVnet",
+				"type":      "Microsoft.Authorization/locks@2016-09-01",
+			},
+			Expected: map[string]string{
+				"resource_id":         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG/providers/Microsoft.Network/virtualNetworks/myVnet/providers/Microsoft.Authorization/locks/myLock",
+				"scope_type":          "Extension",
+				"resource_group_name": "myRG",
+			},
+		},
+		{
+			// the default resource group is used when `parent_id` is omitted
+			Config: map[string]interface{}{
+				"name": "myVnet",
+				"type": "Microsoft.Network/virtualNetworks",
+			},
+			Expected: map[string]string{
+				"resource_id":         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/defaultRG/providers/Microsoft.Network/virtualNetworks/myVnet",
+				"resource_group_name": "defaultRG",
+			},
+		},
+		{
+			Config: map[string]interface{}{
+				"resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Network/virtualNetworks/myVnet",
+			},
+			Error: "`parent_id` is invalid",
+		},
+		{
+			Config: map[string]interface{}{
+				"resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG/providers/Microsoft.Network/virtualNetworks/myVnet",
+				"type":        "Microsoft.Network/virtualNetworks/subnets",
+			},
+			Error: "expect id of `Microsoft.Network/virtualNetworks/subnets`",
+		},
+		{
+			// the api-version is required for the unknown resource types
+			Config: map[string]interface{}{
+				"name":      "myBar",
+				"parent_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/myRG",
+				"type":      "Microsoft.Foo/bars",
+			},
+			Error: "resource type Microsoft.Foo/bars can't be found",
+		},
+	}
+
+	userFeatures := features.Default()
+	userFeatures.DefaultResourceGroupName = "defaultRG"
+	client := &clients.Client{
+		SubscriptionId: "00000000-0000-0000-0000-000000000000",
+		Features:       userFeatures,
+	}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %v", v.Config)
+
+		d := schema.TestResourceDataRaw(t, ResourceAzureGenericResourceIdDataSource().Schema, v.Config)
+		err := resourceAzureGenericResourceIdDataSourceRead(d, client)
+		if err != nil {
+			if v.Error != "" && strings.Contains(err.Error(), v.Error) {
+				continue
+			}
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error != "" {
+			t.Fatalf("Expect an error %q but didn't get one", v.Error)
+		}
+
+		for key, expected := range v.Expected {
+			if actual := d.Get(key); actual != expected {
+				t.Fatalf("Expected %q but got %q for %s", expected, actual, key)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
@@ -122,7 +123,8 @@ func TestAccGenericResource_defaultTags(t *testing.T) {
 			Config: r.defaultTag(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
-				check.That(data.ResourceName).Key("tags.key").HasValue("default"),
+				check.That(data.ResourceName).Key("tags_all.key").HasValue("default"),
+				check.That(data.ResourceName).Key("rendered_body").MatchesRegex(regexp.MustCompile(`"tags":\{"key":"default"\}`)),
 			),
 		},
 		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
@@ -138,7 +140,7 @@ func TestAccGenericResource_defaultTags(t *testing.T) {
 			Config: r.defaultTag(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
-				check.That(data.ResourceName).Key("tags.key").HasValue("default"),
+				check.That(data.ResourceName).Key("tags_all.key").HasValue("default"),
 			),
 		},
 		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
@@ -150,6 +152,17 @@ func TestAccGenericResource_defaultTags(t *testing.T) {
 			),
 		},
 		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
+		{
+			Config: r.defaultTagMerged(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.app").HasValue("web"),
+				check.That(data.ResourceName).Key("tags_all.key").HasValue("default"),
+				check.That(data.ResourceName).Key("tags_all.app").HasValue("web"),
+			),
+		},
+		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
 	})
 }
 
@@ -194,6 +207,22 @@ func TestAccGenericResource_defaultLocation(t *testing.T) {
 	})
 }
 
+func TestAccGenericResource_defaultParent(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.defaultParent(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("parent_id").IsSet(),
+			),
+		},
+		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
+	})
+}
+
 func TestAccGenericResource_subscriptionScope(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azapi_resource", "test")
 	r := GenericResource{}
@@ -227,6 +256,52 @@ func TestAccGenericResource_extensionScope(t *testing.T) {
 	})
 }
 
+func TestAccGenericResource_updateApiVersion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.apiVersion(data, "2020-11-01-preview"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
+		{
+			// the api-version is updated in place
+			Config: r.apiVersion(data, "2021-12-01-preview"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("type").HasValue("Microsoft.ContainerRegistry/registries/scopeMaps@2021-12-01-preview"),
+			),
+		},
+		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
+	})
+}
+
+func TestAccGenericResource_requestOptions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.requestOptions(data, "Developer Scopes"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
+		{
+			Config: r.requestOptions(data, "Updated Scopes"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(r.ImportIdFunc, r.importStateCheckFunc),
+	})
+}
+
 func TestAccGenericResource_ignoreMissingProperty(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azapi_resource", "test")
 	r := GenericResource{}
@@ -279,7 +354,7 @@ func (GenericResource) Exists(ctx context.Context, client *clients.Client, state
 		return nil, err
 	}
 
-	_, _, err = client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion)
+	_, _, err = client.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.RequestOption{})
 	if err == nil {
 		b := true
 		return &b, nil
@@ -345,6 +420,79 @@ resource "azapi_resource" "test" {
 `, r.template(data), data.RandomString)
 }
 
+func (r GenericResource) apiVersion(data acceptance.TestData, apiVersion string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry" "test" {
+  name                = "acctest%[2]s"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Premium"
+  admin_enabled       = false
+}
+
+resource "azapi_resource" "test" {
+  name      = "acctest%[2]s"
+  parent_id = azurerm_container_registry.test.id
+  type      = "Microsoft.ContainerRegistry/registries/scopeMaps@%[3]s"
+  body      = <<BODY
+   {
+      "properties": {
+        "description": "Developer Scopes",
+        "actions": [
+          "repositories/testrepo/content/read"
+        ]
+      }
+    }
+  BODY
+}
+`, r.template(data), data.RandomString, apiVersion)
+}
+
+func (r GenericResource) requestOptions(data acceptance.TestData, description string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry" "test" {
+  name                = "acctest%[2]s"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Premium"
+  admin_enabled       = false
+}
+
+resource "azapi_resource" "test" {
+  name      = "acctest%[2]s"
+  parent_id = azurerm_container_registry.test.id
+  type      = "Microsoft.ContainerRegistry/registries/scopeMaps@2020-11-01-preview"
+  body      = <<BODY
+   {
+      "properties": {
+        "description": "%[3]s",
+        "actions": [
+          "repositories/testrepo/content/read"
+        ]
+      }
+    }
+  BODY
+
+  create_headers = {
+    "x-ms-client-request-id" = "00000000-0000-0000-0000-000000000001"
+  }
+  read_headers = {
+    "x-ms-client-request-id" = "00000000-0000-0000-0000-000000000002"
+  }
+  update_headers = {
+    "If-Match" = "*"
+  }
+  delete_headers = {
+    "x-ms-client-request-id" = "00000000-0000-0000-0000-000000000003"
+  }
+}
+`, r.template(data), data.RandomString, description)
+}
+
 func (r GenericResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -627,6 +775,40 @@ resource "azapi_resource" "test" {
 `, r.template(data), data.RandomString, data.LocationPrimary)
 }
 
+func (r GenericResource) defaultTagMerged(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+provider "azapi" {
+  default_tags = {
+    key = "default"
+  }
+}
+
+resource "azapi_resource" "test" {
+  name      = "acctest%[2]s"
+  parent_id = azurerm_resource_group.test.id
+  type      = "Microsoft.Automation/automationAccounts@2020-01-13-preview"
+
+  location = azurerm_resource_group.test.location
+  identity {
+    type = "SystemAssigned"
+  }
+
+  body = jsonencode({
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  })
+
+  tags = {
+    app = "web"
+  }
+}
+`, r.template(data), data.RandomString, data.LocationPrimary)
+}
+
 func (r GenericResource) defaultLocation(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -723,6 +905,29 @@ resource "azapi_resource" "test" {
 `, r.template(data), data.RandomString, data.LocationPrimary)
 }
 
+func (r GenericResource) defaultParent(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+provider "azapi" {
+  default_resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azapi_resource" "test" {
+  name     = "acctest%[2]s"
+  type     = "Microsoft.Automation/automationAccounts@2020-01-13-preview"
+  location = azurerm_resource_group.test.location
+
+  body = jsonencode({
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  })
+}
+`, r.template(data), data.RandomString)
+}
+
 func (GenericResource) subscriptionScope(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
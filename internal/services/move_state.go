@@ -0,0 +1,55 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+// MoveStateImportId returns the import id of `azapi_resource` for the state of an `azurerm_*` resource, so the resource can be moved to
+// `azapi_resource` with a `moved` block. The ARM id is the `id` attribute of the source state, and the latest stable api-version is used.
+func MoveStateImportId(sourceProviderAddress, sourceTypeName string, sourceState []byte) (string, error) {
+	if !strings.HasSuffix(strings.ToLower(sourceProviderAddress), "hashicorp/azurerm") || !strings.HasPrefix(sourceTypeName, "azurerm_") {
+		return "", fmt.Errorf("moving %s from provider %q is not supported, only the `azurerm_*` resources of the `hashicorp/azurerm` provider can be moved to `azapi_resource`", sourceTypeName, sourceProviderAddress)
+	}
+	// the ids of the associations are the ids of one of the associated resources, moving them would manage the wrong resources
+	if strings.HasSuffix(sourceTypeName, "_association") {
+		return "", fmt.Errorf("moving %s is not supported, its id doesn't identify an azure resource", sourceTypeName)
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(sourceState, &state); err != nil {
+		return "", fmt.Errorf("parsing the state of %s: %+v", sourceTypeName, err)
+	}
+	id, ok := state["id"].(string)
+	if !ok || id == "" {
+		return "", fmt.Errorf("the state of %s doesn't have an `id`", sourceTypeName)
+	}
+	if !strings.HasPrefix(id, "/") {
+		return "", fmt.Errorf("moving %s is not supported, its id %q is not an azure resource manager id", sourceTypeName, id)
+	}
+
+	resourceType := utils.GetResourceType(id)
+	apiVersion := latestStableApiVersion(resourceType)
+	if apiVersion == "" {
+		return "", fmt.Errorf("moving %s is not supported, the resource type %q of id %q can't be found in the embedded schemas", sourceTypeName, resourceType, id)
+	}
+	return fmt.Sprintf("%s?api-version=%s", id, apiVersion), nil
+}
+
+// latestStableApiVersion returns the latest stable api-version of the resource type, or the latest preview api-version if there's no stable one.
+func latestStableApiVersion(resourceType string) string {
+	versions := azure.GetApiVersions(resourceType)
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !azure.IsPreviewApiVersion(versions[i]) {
+			return versions[i]
+		}
+	}
+	if len(versions) != 0 {
+		return versions[len(versions)-1]
+	}
+	return ""
+}
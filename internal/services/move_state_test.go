@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+)
+
+func Test_MoveStateImportId(t *testing.T) {
+	testData := []struct {
+		ProviderAddress string
+		TypeName        string
+		State           string
+		Expected        string
+		ExpectError     bool
+	}{
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+			TypeName:        "azurerm_container_registry",
+			State:           `{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ContainerRegistry/registries/registry1", "name": "registry1"}`,
+			// the latest api-version is a preview, the latest stable one is used
+			Expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ContainerRegistry/registries/registry1?api-version=2021-09-01",
+		},
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+			TypeName:        "azurerm_subnet",
+			State:           `{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1"}`,
+			Expected:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1?api-version=2021-05-01",
+		},
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+			TypeName:        "azurerm_resource_group",
+			State:           `{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1"}`,
+			Expected:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1?api-version=2021-04-01",
+		},
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/aws",
+			TypeName:        "aws_instance",
+			State:           `{"id": "i-00000000"}`,
+			ExpectError:     true,
+		},
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+			TypeName:        "azurerm_subnet_network_security_group_association",
+			State:           `{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1"}`,
+			ExpectError:     true,
+		},
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+			TypeName:        "azurerm_key_vault_secret",
+			State:           `{"id": "https://vault1.vault.azure.net/secrets/secret1/00000000000000000000000000000000"}`,
+			ExpectError:     true,
+		},
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+			TypeName:        "azurerm_container_registry",
+			State:           `{"name": "registry1"}`,
+			ExpectError:     true,
+		},
+		{
+			ProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+			TypeName:        "azurerm_foo",
+			State:           `{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Foo/foos/foo1"}`,
+			ExpectError:     true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s %s", v.TypeName, v.State)
+		actual, err := MoveStateImportId(v.ProviderAddress, v.TypeName, []byte(v.State))
+		if v.ExpectError != (err != nil) {
+			t.Fatalf("Expected error %t but got %v", v.ExpectError, err)
+		}
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/dataplane"
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+type DataPlaneResourceId struct {
+	AzureResourceId   string
+	ApiVersion        string
+	AzureResourceType string
+	Name              string
+	ParentId          string
+	Url               string
+	ResourceDef       *dataplane.ResourceType
+}
+
+// BuildDataPlaneResourceID builds the id of the data-plane resource, the id is in a format like `<parent_id>/<last segment of type>/<name>`.
+func BuildDataPlaneResourceID(name, parentId, resourceType, environment string) (DataPlaneResourceId, error) {
+	inputType, apiVersion, ok := utils.SplitResourceType(resourceType)
+	if !ok {
+		return DataPlaneResourceId{}, fmt.Errorf("`type` is invalid, expect `<resource-type>@<api-version>` but got %q", resourceType)
+	}
+	azureResourceType, resourceDef := dataplane.GetResourceType(inputType)
+	if resourceDef == nil {
+		return DataPlaneResourceId{}, fmt.Errorf("`type` is invalid, resource type %s is not supported, the supported types are [%s]",
+			inputType, strings.Join(dataplane.ResourceTypes(), ", "))
+	}
+	isVersionValid := false
+	for _, version := range resourceDef.ApiVersions {
+		if version == apiVersion {
+			isVersionValid = true
+			break
+		}
+	}
+	if !isVersionValid {
+		return DataPlaneResourceId{}, fmt.Errorf("the `type`'s api-version is invalid. The supported versions are [%s]", strings.Join(resourceDef.ApiVersions, ", "))
+	}
+
+	if parentIdType := utils.GetResourceType(parentId); !strings.EqualFold(parentIdType, resourceDef.ParentType) {
+		return DataPlaneResourceId{}, fmt.Errorf("`parent_id` is invalid, expect id of `%s`", resourceDef.ParentType)
+	}
+
+	dataPlaneUrl, err := resourceDef.BuildUrl(parentId, name, environment)
+	if err != nil {
+		return DataPlaneResourceId{}, err
+	}
+
+	typeParts := strings.Split(azureResourceType, "/")
+	return DataPlaneResourceId{
+		AzureResourceId:   fmt.Sprintf("%s/%s/%s", parentId, typeParts[len(typeParts)-1], name),
+		ApiVersion:        apiVersion,
+		AzureResourceType: azureResourceType,
+		Name:              name,
+		ParentId:          parentId,
+		Url:               dataPlaneUrl,
+		ResourceDef:       resourceDef,
+	}, nil
+}
+
+// DataPlaneResourceID parses a data-plane resource id in a format like `<parent_id>/<last segment of type>/<name>?api-version=<api-version>`.
+func DataPlaneResourceID(input, environment string) (DataPlaneResourceId, error) {
+	idUrl, err := url.Parse(input)
+	if err != nil {
+		return DataPlaneResourceId{}, err
+	}
+
+	azureResourceId := idUrl.Path
+	apiVersion := idUrl.Query().Get("api-version")
+	if azureResourceId == "" {
+		return DataPlaneResourceId{}, fmt.Errorf("ID was missing the 'azure resource id' element")
+	}
+	if apiVersion == "" {
+		return DataPlaneResourceId{}, fmt.Errorf("ID was missing the 'api-version' element")
+	}
+
+	// the name may contain `/`, so the parent id is found by the resource type's last segment
+	for _, resourceType := range dataplane.ResourceTypes() {
+		typeParts := strings.Split(resourceType, "/")
+		segment := fmt.Sprintf("/%s/", strings.ToLower(typeParts[len(typeParts)-1]))
+		index := strings.LastIndex(strings.ToLower(azureResourceId), segment)
+		if index == -1 {
+			continue
+		}
+		parentId := azureResourceId[0:index]
+		name := azureResourceId[index+len(segment):]
+		_, resourceDef := dataplane.GetResourceType(resourceType)
+		if !strings.EqualFold(utils.GetResourceType(parentId), resourceDef.ParentType) {
+			continue
+		}
+		return BuildDataPlaneResourceID(name, parentId, fmt.Sprintf("%s@%s", resourceType, apiVersion), environment)
+	}
+	return DataPlaneResourceId{}, fmt.Errorf("ID %q doesn't match any supported data-plane resource type [%s]", input, strings.Join(dataplane.ResourceTypes(), ", "))
+}
+
+func (id DataPlaneResourceId) String() string {
+	segments := []string{
+		fmt.Sprintf("ResourceId %q", id.AzureResourceId),
+		fmt.Sprintf("Api Version %q", id.ApiVersion),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Data Plane Resource", segmentsStr)
+}
+
+func (id DataPlaneResourceId) ID() string {
+	return id.AzureResourceId
+}
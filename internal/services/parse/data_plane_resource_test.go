@@ -0,0 +1,89 @@
+package parse
+
+import "testing"
+
+func Test_BuildDataPlaneResourceID(t *testing.T) {
+	testData := []struct {
+		Name         string
+		ParentId     string
+		ResourceType string
+		Expected     string
+		ExpectedUrl  string
+		Error        bool
+	}{
+		{
+			Name:         "secret1",
+			ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			ResourceType: "Microsoft.KeyVault/vaults/secrets@7.4",
+			Expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1/secrets/secret1",
+			ExpectedUrl:  "https://vault1.vault.azure.net/secrets/secret1",
+		},
+		{
+			Name:         "partition1/row1",
+			ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/account1/tableServices/default/tables/table1",
+			ResourceType: "Microsoft.Storage/storageAccounts/tableServices/tables/entities@2019-02-02",
+			Expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.Storage/storageAccounts/account1/tableServices/default/tables/table1/entities/partition1/row1",
+			ExpectedUrl:  "https://account1.table.core.windows.net/table1(PartitionKey='partition1',RowKey='row1')",
+		},
+		{
+			// the key contains `/`
+			Name:         "app1/settings/color",
+			ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.AppConfiguration/configurationStores/store1",
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues@1.0",
+			Expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.AppConfiguration/configurationStores/store1/keyValues/app1/settings/color",
+			ExpectedUrl:  "https://store1.azconfig.io/kv/app1%2Fsettings%2Fcolor",
+		},
+		{
+			// unsupported api-version
+			Name:         "secret1",
+			ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			ResourceType: "Microsoft.KeyVault/vaults/secrets@2016-10-01",
+			Error:        true,
+		},
+		{
+			// unsupported type
+			Name:         "key1",
+			ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			ResourceType: "Microsoft.KeyVault/vaults/keys@7.4",
+			Error:        true,
+		},
+		{
+			// parent id doesn't match
+			Name:         "key1",
+			ParentId:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.KeyVault/vaults/vault1",
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues@1.0",
+			Error:        true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q %q %q", v.Name, v.ParentId, v.ResourceType)
+
+		actual, err := BuildDataPlaneResourceID(v.Name, v.ParentId, v.ResourceType, "public")
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AzureResourceId != v.Expected {
+			t.Fatalf("Expected %q but got %q for AzureResourceId", v.Expected, actual.AzureResourceId)
+		}
+		if actual.Url != v.ExpectedUrl {
+			t.Fatalf("Expected %q but got %q for Url", v.ExpectedUrl, actual.Url)
+		}
+
+		parsed, err := DataPlaneResourceID(actual.AzureResourceId+"?api-version="+actual.ApiVersion, "public")
+		if err != nil {
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if parsed.Name != v.Name || parsed.ParentId != v.ParentId || parsed.Url != v.ExpectedUrl {
+			t.Fatalf("Expected %+v but got %+v", actual, parsed)
+		}
+	}
+}
@@ -21,12 +21,9 @@ type ResourceId struct {
 }
 
 func BuildResourceID(name, parentId, resourceType string) (ResourceId, error) {
-	parts := strings.Split(resourceType, "@")
-	apiVersion := ""
-	azureResourceType := ""
-	if len(parts) == 2 {
-		apiVersion = parts[1]
-		azureResourceType = parts[0]
+	azureResourceType, apiVersion, ok := utils.SplitResourceType(resourceType)
+	if !ok {
+		azureResourceType = ""
 	}
 
 	resourceDef, err := azure.GetResourceDefinition(azureResourceType, apiVersion)
@@ -34,50 +31,19 @@ func BuildResourceID(name, parentId, resourceType string) (ResourceId, error) {
 		log.Printf("[ERROR] load embedded schema: %+v\n", err)
 	}
 
-	scopeTypes := make([]types.ScopeType, 0)
-	if resourceDef != nil {
-		for _, scope := range resourceDef.ScopeTypes {
-			if scope != types.Unknown {
-				scopeTypes = append(scopeTypes, scope)
-			}
-		}
-	}
-	parentIdScope := utils.GetScopeType(parentId)
 	parentIdExpectedType := utils.GetParentType(azureResourceType)
-	parentIdType := utils.GetResourceType(parentId)
-
-	for _, v := range []string{"Tenant", "Subscription", "Microsoft.Resources/resourceGroups", "Microsoft.Management/managementGroups"} {
-		if v == parentIdType {
-			parentIdType = ""
-		}
-	}
+	parentIdType := parentResourceType(parentId)
 
 	azureResourceId := ""
 	isExtension := false
 	// known scope, use `type` to verify `parent_id`
+	scopeTypes := make([]types.ScopeType, 0)
+	if resourceDef != nil {
+		scopeTypes = knownScopeTypes(resourceDef.ScopeTypes)
+	}
 	if len(scopeTypes) != 0 {
-		// check parent_id's scope
-		matchedScope := types.Unknown
-		err = nil
-		for _, scope := range scopeTypes {
-			if scope == parentIdScope {
-				if strings.EqualFold(parentIdExpectedType, parentIdType) {
-					matchedScope = scope
-					break
-				} else {
-					err = fmt.Errorf("`parent_id` is invalid, expect id of `%s`", parentIdExpectedType)
-				}
-			}
-			if scope == types.Extension && parentIdScope == types.ResourceGroup {
-				matchedScope = scope
-				break
-			}
-		}
-		if matchedScope == types.Unknown {
-			if err == nil {
-				err = fmt.Errorf("`parent_id` is invalid, expect id of resource whose scope is %v, but got scope %v",
-					scopeTypes, parentIdScope)
-			}
+		matchedScope, err := matchScope(scopeTypes, parentId, azureResourceType)
+		if err != nil {
 			return ResourceId{
 				AzureResourceId:   azureResourceId,
 				ApiVersion:        apiVersion,
@@ -87,7 +53,6 @@ func BuildResourceID(name, parentId, resourceType string) (ResourceId, error) {
 				ResourceDef:       resourceDef,
 			}, err
 		}
-
 		isExtension = matchedScope == types.Extension
 	} else {
 		// scope is unknown
@@ -121,6 +86,128 @@ func BuildResourceID(name, parentId, resourceType string) (ResourceId, error) {
 	}, nil
 }
 
+// CheckWritableScope returns an error if the resource type can't be created or updated in the scope of the parent id,
+// the resource types may be readable in more scopes than the writable ones.
+func (id ResourceId) CheckWritableScope() error {
+	if id.ResourceDef == nil || id.ResourceDef.WritableScopeTypes == nil {
+		return nil
+	}
+	scopeTypes := knownScopeTypes(id.ResourceDef.WritableScopeTypes)
+	if len(scopeTypes) == 0 {
+		return fmt.Errorf("resource type %s is read-only, it can't be created or updated", id.AzureResourceType)
+	}
+	_, err := matchScope(scopeTypes, id.ParentId, id.AzureResourceType)
+	return err
+}
+
+// ScopeType returns the scope of the resource, it's Extension if the parent is not the resource type's parent type, like a lock of a virtual network.
+func (id ResourceId) ScopeType() types.ScopeType {
+	if id.ParentId != "" && !strings.EqualFold(utils.GetParentType(id.AzureResourceType), parentResourceType(id.ParentId)) {
+		return types.Extension
+	}
+	return utils.GetScopeType(id.AzureResourceId)
+}
+
+// matchScope returns the scope in the scope types which matches the parent id.
+func matchScope(scopeTypes []types.ScopeType, parentId string, azureResourceType string) (types.ScopeType, error) {
+	parentIdScope := utils.GetScopeType(parentId)
+	parentIdExpectedType := utils.GetParentType(azureResourceType)
+	parentIdType := parentResourceType(parentId)
+
+	var err error
+	for _, scope := range scopeTypes {
+		if scope == parentIdScope {
+			if strings.EqualFold(parentIdExpectedType, parentIdType) {
+				return scope, nil
+			}
+			err = fmt.Errorf("`parent_id` is invalid, expect id of `%s`", parentIdExpectedType)
+		}
+		if scope == types.Extension && parentIdScope == types.ResourceGroup {
+			return scope, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("`parent_id` is invalid, expect id of resource whose scope is %v, but got scope %v", scopeTypes, parentIdScope)
+	}
+	return types.Unknown, err
+}
+
+// parentResourceType returns the resource type of the parent id, it's empty if the parent id is a scope like a subscription.
+func parentResourceType(parentId string) string {
+	parentIdType := utils.GetResourceType(parentId)
+	for _, v := range []string{"Tenant", "Subscription", "Microsoft.Resources/resourceGroups", "Microsoft.Management/managementGroups"} {
+		if v == parentIdType {
+			return ""
+		}
+	}
+	return parentIdType
+}
+
+func knownScopeTypes(input []types.ScopeType) []types.ScopeType {
+	scopeTypes := make([]types.ScopeType, 0)
+	for _, scope := range input {
+		if scope != types.Unknown {
+			scopeTypes = append(scopeTypes, scope)
+		}
+	}
+	return scopeTypes
+}
+
+// DefaultParent contains the provider level settings which are used to build the `parent_id` when it's omitted.
+type DefaultParent struct {
+	SubscriptionId    string
+	ParentId          string
+	ResourceGroupName string
+}
+
+// BuildResourceIDWithDefaultParent builds the resource id when `parent_id` is omitted. The candidates are the default parent id
+// and the default resource group, the first one whose scope is allowed by the resource type is used.
+// The subscription and the tenant are not used unless they're configured as the default parent id.
+func BuildResourceIDWithDefaultParent(name string, defaultParent DefaultParent, resourceType string) (ResourceId, error) {
+	azureResourceType, _, _ := utils.SplitResourceType(resourceType)
+	if utils.GetParentType(azureResourceType) != "" {
+		return ResourceId{}, fmt.Errorf("`parent_id` is required for child resource type %s", azureResourceType)
+	}
+
+	candidates := make([]string, 0)
+	if defaultParent.ParentId != "" {
+		candidates = append(candidates, defaultParent.ParentId)
+	}
+	if defaultParent.SubscriptionId != "" && defaultParent.ResourceGroupName != "" {
+		candidates = append(candidates, fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", defaultParent.SubscriptionId, defaultParent.ResourceGroupName))
+	}
+	if len(candidates) == 0 {
+		return ResourceId{}, fmt.Errorf("`parent_id` is required for resource type %s, because neither `default_parent_id` nor `default_resource_group_name` is configured in the provider", azureResourceType)
+	}
+
+	scopeTypes := make([]types.ScopeType, 0)
+	for _, parentId := range candidates {
+		id, err := BuildResourceID(name, parentId, resourceType)
+		if err == nil {
+			return id, nil
+		}
+		if id.ResourceDef != nil {
+			scopeTypes = id.ResourceDef.ScopeTypes
+		}
+	}
+	return ResourceId{}, fmt.Errorf("`parent_id` is required, none of the default parent ids [%s] matches the scopes %v of resource type %s",
+		strings.Join(candidates, ", "), scopeTypes, azureResourceType)
+}
+
+// ResourceTypeWithApiVersion appends the latest api-version to the resource type if it's not in a format like `<resource-type>@<api-version>`.
+// The resource type is the same in all api-versions, so the latest api-version is used to check the scope when the api-version is not specified.
+// It returns an error if the api-version is not specified and the resource type can't be found in the embedded schemas.
+func ResourceTypeWithApiVersion(resourceType string) (string, error) {
+	if _, _, ok := utils.SplitResourceType(resourceType); ok {
+		return resourceType, nil
+	}
+	versions := azure.GetApiVersions(resourceType)
+	if len(versions) == 0 {
+		return "", fmt.Errorf("resource type %s can't be found, the api-version must be specified in a format like `<resource-type>@<api-version>`", resourceType)
+	}
+	return fmt.Sprintf("%s@%s", resourceType, versions[len(versions)-1]), nil
+}
+
 func NewResourceID(azureResourceId, resourceType string) (ResourceId, error) {
 	name := utils.GetName(azureResourceId)
 	parentId := utils.GetParentId(azureResourceId)
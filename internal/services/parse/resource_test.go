@@ -1,6 +1,10 @@
 package parse
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
 
 func TestResourceIDFormatter(t *testing.T) {
 	id, err := NewResourceID("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.EventHub/clusters/cluster1", "Microsoft.EventHub/clusters@2020-12-01")
@@ -405,3 +409,218 @@ func Test_BuildResourceID(t *testing.T) {
 		}
 	}
 }
+
+func Test_BuildResourceIDWithDefaultParent(t *testing.T) {
+	defaultParent := DefaultParent{
+		SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+		ResourceGroupName: "group1",
+	}
+	testData := []struct {
+		Name          string
+		DefaultParent DefaultParent
+		ResourceType  string
+		Error         bool
+		Expected      string
+	}{
+		{
+			// resource group scope, use the default resource group
+			Name:          "test",
+			DefaultParent: defaultParent,
+			ResourceType:  "Microsoft.ContainerRegistry/registries@2020-11-01-preview",
+			Expected:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ContainerRegistry/registries/test",
+		},
+
+		{
+			// subscription scope, the subscription isn't used unless it's the default parent id
+			Name:          "test",
+			DefaultParent: defaultParent,
+			ResourceType:  "Microsoft.Resources/resourceGroups@2021-04-01",
+			Error:         true,
+		},
+
+		{
+			// subscription scope, use the default parent id
+			Name: "test",
+			DefaultParent: DefaultParent{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ParentId:          "/subscriptions/12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "group1",
+			},
+			ResourceType: "Microsoft.Resources/resourceGroups@2021-04-01",
+			Expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/test",
+		},
+
+		{
+			// default resource group is used when the default parent id's scope isn't allowed
+			Name: "test",
+			DefaultParent: DefaultParent{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ParentId:          "/subscriptions/12345678-1234-9876-4563-123456789012",
+				ResourceGroupName: "group1",
+			},
+			ResourceType: "Microsoft.ContainerRegistry/registries@2020-11-01-preview",
+			Expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ContainerRegistry/registries/test",
+		},
+
+		{
+			// tenant scope, the tenant isn't used unless it's the default parent id
+			Name:          "test",
+			DefaultParent: defaultParent,
+			ResourceType:  "Microsoft.Management/managementGroups@2021-04-01",
+			Error:         true,
+		},
+
+		{
+			// no default parent
+			Name:          "test",
+			DefaultParent: DefaultParent{SubscriptionId: "12345678-1234-9876-4563-123456789012"},
+			ResourceType:  "Microsoft.Resources/resourceGroups@2021-04-01",
+			Error:         true,
+		},
+
+		{
+			// default parent id takes precedence over the default resource group
+			Name: "test",
+			DefaultParent: DefaultParent{
+				SubscriptionId:    "12345678-1234-9876-4563-123456789012",
+				ParentId:          "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group2",
+				ResourceGroupName: "group1",
+			},
+			ResourceType: "Microsoft.ContainerRegistry/registries@2020-11-01-preview",
+			Expected:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group2/providers/Microsoft.ContainerRegistry/registries/test",
+		},
+
+		{
+			// no default resource group for a resource group scoped resource
+			Name:          "test",
+			DefaultParent: DefaultParent{},
+			ResourceType:  "Microsoft.ContainerRegistry/registries@2020-11-01-preview",
+			Error:         true,
+		},
+
+		{
+			// child resource requires parent_id
+			Name:          "test",
+			DefaultParent: defaultParent,
+			ResourceType:  "Microsoft.ContainerRegistry/registries/scopeMaps@2020-11-01-preview",
+			Error:         true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q %v %q", v.Name, v.DefaultParent, v.ResourceType)
+
+		actual, err := BuildResourceIDWithDefaultParent(v.Name, v.DefaultParent, v.ResourceType)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AzureResourceId != v.Expected {
+			t.Fatalf("Expected %q but got %q for AzureResourceId", v.Expected, actual.AzureResourceId)
+		}
+	}
+}
+
+func Test_ResourceTypeWithApiVersion(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected string
+		Error    bool
+	}{
+		{
+			Input:    "Microsoft.Network/virtualNetworks@2021-02-01",
+			Expected: "Microsoft.Network/virtualNetworks@2021-02-01",
+		},
+		{
+			Input:    "Microsoft.Foo/bars@2021-01-01",
+			Expected: "Microsoft.Foo/bars@2021-01-01",
+		},
+		{
+			Input: "Microsoft.Foo/bars",
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := ResourceTypeWithApiVersion(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+		if actual != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, actual)
+		}
+	}
+
+	actual, err := ResourceTypeWithApiVersion("Microsoft.Network/virtualNetworks")
+	if err != nil {
+		t.Fatalf("Expect a value but got an error: %s", err)
+	}
+	if _, err := NewResourceID("/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/vnet1", actual); err != nil {
+		t.Fatalf("Expect a value but got an error: %s", err)
+	}
+}
+
+func Test_CheckWritableScope(t *testing.T) {
+	resourceGroupId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1"
+	subscriptionId := "/subscriptions/12345678-1234-9876-4563-123456789012"
+	testData := []struct {
+		ParentId    string
+		ResourceDef *types.ResourceType
+		Error       bool
+	}{
+		{
+			ParentId: subscriptionId,
+		},
+		{
+			// the writable scopes are the same as the readable scopes
+			ParentId:    subscriptionId,
+			ResourceDef: &types.ResourceType{ScopeTypes: []types.ScopeType{types.Subscription, types.ResourceGroup}},
+		},
+		{
+			ParentId:    resourceGroupId,
+			ResourceDef: &types.ResourceType{ScopeTypes: []types.ScopeType{types.Subscription, types.ResourceGroup}, WritableScopeTypes: []types.ScopeType{types.ResourceGroup}},
+		},
+		{
+			// the scope is only readable
+			ParentId:    subscriptionId,
+			ResourceDef: &types.ResourceType{ScopeTypes: []types.ScopeType{types.Subscription, types.ResourceGroup}, WritableScopeTypes: []types.ScopeType{types.ResourceGroup}},
+			Error:       true,
+		},
+		{
+			// the resource type is read-only
+			ParentId:    resourceGroupId,
+			ResourceDef: &types.ResourceType{ScopeTypes: []types.ScopeType{types.ResourceGroup}, WritableScopeTypes: []types.ScopeType{}},
+			Error:       true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q %v", v.ParentId, v.ResourceDef)
+		id := ResourceId{
+			AzureResourceId:   v.ParentId + "/providers/Microsoft.Test/tests/test1",
+			AzureResourceType: "Microsoft.Test/tests",
+			Name:              "test1",
+			ParentId:          v.ParentId,
+			ResourceDef:       v.ResourceDef,
+		}
+		err := id.CheckWritableScope()
+		if v.Error != (err != nil) {
+			t.Fatalf("Expected error %t but got %v", v.Error, err)
+		}
+	}
+}
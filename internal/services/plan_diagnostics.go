@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// ErrPlanDiagnostics is returned by the CustomizeDiff when its errors are collected by the context created by WithPlanDiagnostics,
+// the provider server replaces it with the collected errors.
+var ErrPlanDiagnostics = errors.New("the plan has errors")
+
+type planDiagnosticsKey struct{}
+
+type planDiagnostics struct {
+	mutex sync.Mutex
+	diags diag.Diagnostics
+}
+
+// WithPlanDiagnostics returns a context which collects the diagnostics during the plan, and a function which returns the collected diagnostics.
+// The CustomizeDiff can only return an error, so the warnings and the errors are collected in the context and returned by the provider server.
+func WithPlanDiagnostics(ctx context.Context) (context.Context, func() diag.Diagnostics) {
+	collector := &planDiagnostics{}
+	return context.WithValue(ctx, planDiagnosticsKey{}, collector), func() diag.Diagnostics {
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+		return collector.diags
+	}
+}
+
+// addPlanWarnings adds the warnings to the context created by WithPlanDiagnostics, the other diagnostics are ignored.
+func addPlanWarnings(ctx context.Context, diags diag.Diagnostics) {
+	collector, ok := ctx.Value(planDiagnosticsKey{}).(*planDiagnostics)
+	if !ok {
+		return
+	}
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			collector.diags = append(collector.diags, d)
+		}
+	}
+}
+
+// planDiagnosticsError returns an error if there're errors in the diagnostics. The errors are added to the context created by
+// WithPlanDiagnostics, so each of them is reported separately, otherwise they're joined to one error.
+func planDiagnosticsError(ctx context.Context, diags diag.Diagnostics) error {
+	if !diags.HasError() {
+		return nil
+	}
+	collector, ok := ctx.Value(planDiagnosticsKey{}).(*planDiagnostics)
+	if !ok {
+		return diagnosticsError(diags)
+	}
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			collector.diags = append(collector.diags, d)
+		}
+	}
+	return ErrPlanDiagnostics
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/identity"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/location"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/tags"
+	"github.com/Azure/terraform-provider-azapi/internal/features"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/services/rules"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// requestBody contains the `body` of `azapi_resource` and the attributes which are merged into it. Both the plan and the apply
+// render the request body with renderRequestBody, so the `rendered_body` in the plan is the same as the body sent to Azure.
+type requestBody struct {
+	Body string
+	// Tags is merged into the body if TagsConfigured is true, otherwise the tags in the body are used
+	Tags           map[string]interface{}
+	TagsConfigured bool
+	// Location and Identity are merged into the body if they're not empty, they must not be specified in both the config and the body
+	Location           string
+	LocationConfigured bool
+	Identity           []interface{}
+	IdentityConfigured bool
+	DefaultTags        map[string]string
+	DefaultLocation    string
+	// the ignored tags are excluded from the `tags_all`, and their values in RemoteTags are kept in the body
+	IgnoreTagKeys        []string
+	IgnoreTagKeyPrefixes []string
+	RemoteTags           map[string]interface{}
+}
+
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type resourceGetter interface {
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+}
+
+// newRequestBody reads the request body's inputs from the resource and the provider's features.
+func newRequestBody(d resourceGetter, features features.UserFeatures) requestBody {
+	config := d.GetRawConfig()
+	return requestBody{
+		Body:                 d.Get("body").(string),
+		Tags:                 d.Get("tags").(map[string]interface{}),
+		TagsConfigured:       isConfigExist(config, "tags"),
+		Location:             d.Get("location").(string),
+		LocationConfigured:   isConfigExist(config, "location"),
+		Identity:             d.Get("identity").([]interface{}),
+		IdentityConfigured:   isConfigExist(config, "identity"),
+		DefaultTags:          features.DefaultTags,
+		DefaultLocation:      features.DefaultLocation,
+		IgnoreTagKeys:        features.IgnoreTagKeys,
+		IgnoreTagKeyPrefixes: features.IgnoreTagKeyPrefixes,
+	}
+}
+
+// renderedBody is the request body with the tags, the location and the identity merged.
+type renderedBody struct {
+	Body map[string]interface{}
+	// TagsAll is the tags in the request body, including the provider's default tags and excluding the ignored tags
+	TagsAll map[string]string
+	// DefaultLocation is the provider's default location if it's used in the request body, otherwise it's empty
+	DefaultLocation string
+}
+
+// renderRequestBody merges the tags, the location and the identity into the body, the provider's default tags and default location
+// are used if the resource type supports them. The remote values of the ignored tags are kept, so the tags managed outside of Terraform aren't removed by the request.
+func renderRequestBody(id parse.ResourceId, input requestBody) (*renderedBody, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(input.Body), &body); err != nil {
+		return nil, err
+	}
+	if body == nil {
+		body = make(map[string]interface{})
+	}
+
+	configured := map[string]bool{
+		"identity": input.IdentityConfigured,
+		"location": input.LocationConfigured,
+		"tags":     input.TagsConfigured,
+	}
+	for _, prop := range []string{"identity", "location", "tags"} {
+		if configured[prop] && body[prop] != nil {
+			return nil, fmt.Errorf("can't specify both property `%[1]s` and `%[1]s` in `body`", prop)
+		}
+	}
+
+	res := &renderedBody{
+		Body:    body,
+		TagsAll: make(map[string]string),
+	}
+	tagsAll := expandTagsAll(input.TagsConfigured, input.Tags, body, id, input.DefaultTags)
+	for key, value := range tagsAll {
+		if !tags.IsIgnoredTag(key, input.IgnoreTagKeys, input.IgnoreTagKeyPrefixes) {
+			res.TagsAll[key] = value
+		}
+	}
+	if isResourceHasProperty(id.ResourceDef, "tags") {
+		tagsAll = tags.MergeIgnoredTags(tagsAll, input.RemoteTags, input.IgnoreTagKeys, input.IgnoreTagKeyPrefixes)
+	}
+	if tagsAll != nil {
+		body["tags"] = tagsAll
+	}
+
+	switch {
+	case !input.LocationConfigured && body["location"] == nil && input.DefaultLocation != "" && isResourceHasProperty(id.ResourceDef, "location"):
+		body["location"] = location.Normalize(input.DefaultLocation)
+		res.DefaultLocation = input.DefaultLocation
+	case input.Location != "":
+		body["location"] = location.Normalize(input.Location)
+	}
+
+	if len(input.Identity) != 0 {
+		identityModel, err := identity.ExpandIdentity(input.Identity)
+		if err != nil {
+			return nil, err
+		}
+		if identityModel != nil {
+			body["identity"] = identityModel
+		}
+	}
+	return res, nil
+}
+
+// expandTagsAll returns the tags of the resource merged with the default tags, the resource's tags take precedence.
+// It returns nil if there are no tags at all.
+func expandTagsAll(tagsConfigured bool, configTags map[string]interface{}, body map[string]interface{}, id parse.ResourceId, defaultTags map[string]string) map[string]string {
+	var resourceTags map[string]string
+	switch {
+	case tagsConfigured:
+		resourceTags = tags.ExpandTags(configTags)
+	case body["tags"] != nil:
+		if bodyTags, ok := body["tags"].(map[string]interface{}); ok {
+			resourceTags = tags.ExpandTags(bodyTags)
+		}
+	}
+	if !isResourceHasProperty(id.ResourceDef, "tags") {
+		defaultTags = nil
+	}
+	if len(resourceTags) == 0 && len(defaultTags) == 0 {
+		return nil
+	}
+	return tags.MergeTags(defaultTags, resourceTags)
+}
+
+// OfflineRequestBody contains the literal values of an `azapi_resource` block and its provider's defaults, it's validated without calling Azure.
+type OfflineRequestBody struct {
+	// Type is in a format like `<resource-type>@<api-version>`
+	Type string
+	Body interface{}
+	// Tags, Location and Identity are the top level arguments, they're not configured if they're empty
+	Tags            map[string]interface{}
+	Location        string
+	Identity        []interface{}
+	DefaultTags     map[string]string
+	DefaultLocation string
+}
+
+// RenderOfflineRequestBody renders the request body the same as the plan of `azapi_resource`.
+func RenderOfflineRequestBody(input OfflineRequestBody) (interface{}, error) {
+	id := offlineResourceId(input.Type)
+	data, err := json.Marshal(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := renderRequestBody(id, requestBody{
+		Body:               string(data),
+		Tags:               input.Tags,
+		TagsConfigured:     input.Tags != nil,
+		Location:           input.Location,
+		LocationConfigured: input.Location != "",
+		Identity:           input.Identity,
+		IdentityConfigured: len(input.Identity) != 0,
+		DefaultTags:        input.DefaultTags,
+		DefaultLocation:    input.DefaultLocation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return utils.NormalizeObject(rendered.Body), nil
+}
+
+// ValidateRequestBody renders the request body the same as the plan of `azapi_resource`, and validates it the same as the schema validation.
+// The errors of the invalid properties are *azureutils.ValidationError, the resource type itself isn't validated.
+func ValidateRequestBody(input OfflineRequestBody, ruleSet *rules.RuleSet) []error {
+	body, err := RenderOfflineRequestBody(input)
+	if err != nil {
+		return []error{err}
+	}
+	errs, _ := bodyValidationErrors(offlineResourceId(input.Type), body, ruleSet)
+	return errs
+}
+
+func offlineResourceId(input string) parse.ResourceId {
+	resourceType, apiVersion, _ := utils.SplitResourceType(input)
+	id := parse.ResourceId{
+		AzureResourceType: resourceType,
+		ApiVersion:        apiVersion,
+	}
+	id.ResourceDef, _ = azure.GetResourceDefinition(resourceType, apiVersion)
+	return id
+}
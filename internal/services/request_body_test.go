@@ -0,0 +1,186 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+)
+
+func Test_RenderRequestBody(t *testing.T) {
+	registryId, err := parse.BuildResourceID("registry1", "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1", "Microsoft.ContainerRegistry/registries@2021-09-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scopeMapId, err := parse.BuildResourceID("scopeMap1", registryId.AzureResourceId, "Microsoft.ContainerRegistry/registries/scopeMaps@2020-11-01-preview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity1"
+
+	testData := []struct {
+		Id              parse.ResourceId
+		Input           requestBody
+		Expected        string
+		TagsAll         map[string]string
+		DefaultLocation string
+		ExpectError     bool
+	}{
+		{
+			// the default tags and the default location are used
+			Id: registryId,
+			Input: requestBody{
+				Body:            `{"sku": {"name": "Basic"}}`,
+				DefaultTags:     map[string]string{"env": "test"},
+				DefaultLocation: "West Europe",
+			},
+			Expected:        `{"location":"westeurope","sku":{"name":"Basic"},"tags":{"env":"test"}}`,
+			TagsAll:         map[string]string{"env": "test"},
+			DefaultLocation: "West Europe",
+		},
+		{
+			// the tags and the location in the config take precedence
+			Id: registryId,
+			Input: requestBody{
+				Body:               `{"sku": {"name": "Basic"}}`,
+				Tags:               map[string]interface{}{"env": "prod", "owner": "me"},
+				TagsConfigured:     true,
+				Location:           "East US",
+				LocationConfigured: true,
+				DefaultTags:        map[string]string{"env": "test", "team": "azapi"},
+				DefaultLocation:    "West Europe",
+			},
+			Expected: `{"location":"eastus","sku":{"name":"Basic"},"tags":{"env":"prod","owner":"me","team":"azapi"}}`,
+			TagsAll:  map[string]string{"env": "prod", "owner": "me", "team": "azapi"},
+		},
+		{
+			// the tags and the location in the body are used if they're not in the config
+			Id: registryId,
+			Input: requestBody{
+				Body:            `{"location": "eastus", "tags": {"owner": "me"}}`,
+				Location:        "eastus",
+				DefaultTags:     map[string]string{"env": "test"},
+				DefaultLocation: "West Europe",
+			},
+			Expected: `{"location":"eastus","tags":{"env":"test","owner":"me"}}`,
+			TagsAll:  map[string]string{"env": "test", "owner": "me"},
+		},
+		{
+			// the ignored tags are excluded from the tags_all, and their remote values are kept in the body
+			Id: registryId,
+			Input: requestBody{
+				Body:                 `{"location": "eastus"}`,
+				Location:             "eastus",
+				Tags:                 map[string]interface{}{"owner": "me", "policy-owner": "me"},
+				TagsConfigured:       true,
+				DefaultTags:          map[string]string{"env": "test"},
+				IgnoreTagKeys:        []string{"env"},
+				IgnoreTagKeyPrefixes: []string{"policy-"},
+				RemoteTags:           map[string]interface{}{"env": "prod", "owner": "you", "policy-owner": "policy", "policy-cost": "1"},
+			},
+			Expected: `{"location":"eastus","tags":{"env":"prod","owner":"me","policy-cost":"1","policy-owner":"policy"}}`,
+			TagsAll:  map[string]string{"owner": "me"},
+		},
+		{
+			// the remote ignored tags are kept even if there're no tags in the config
+			Id: registryId,
+			Input: requestBody{
+				Body:          `{"location": "eastus"}`,
+				Location:      "eastus",
+				IgnoreTagKeys: []string{"env"},
+				RemoteTags:    map[string]interface{}{"env": "prod", "owner": "you"},
+			},
+			Expected: `{"location":"eastus","tags":{"env":"prod"}}`,
+			TagsAll:  map[string]string{},
+		},
+		{
+			Id: registryId,
+			Input: requestBody{
+				Body: `{}`,
+				Identity: []interface{}{
+					map[string]interface{}{
+						"type":         "UserAssigned",
+						"identity_ids": []interface{}{identityId},
+					},
+				},
+				IdentityConfigured: true,
+			},
+			Expected: `{"identity":{"type":"UserAssigned","userAssignedIdentities":{"` + identityId + `":{}}}}`,
+			TagsAll:  map[string]string{},
+		},
+		{
+			// the resource type doesn't support the tags and the location
+			Id: scopeMapId,
+			Input: requestBody{
+				Body:            `{"properties": {"actions": []}}`,
+				DefaultTags:     map[string]string{"env": "test"},
+				DefaultLocation: "West Europe",
+			},
+			Expected: `{"properties":{"actions":[]}}`,
+			TagsAll:  map[string]string{},
+		},
+		{
+			Id: registryId,
+			Input: requestBody{
+				Body: `null`,
+			},
+			Expected: `{}`,
+			TagsAll:  map[string]string{},
+		},
+		{
+			Id: registryId,
+			Input: requestBody{
+				Body:           `{"tags": {"owner": "me"}}`,
+				Tags:           map[string]interface{}{"owner": "me"},
+				TagsConfigured: true,
+			},
+			ExpectError: true,
+		},
+		{
+			Id: registryId,
+			Input: requestBody{
+				Body: `{"identity": {"type": "SystemAssigned"}}`,
+				Identity: []interface{}{
+					map[string]interface{}{
+						"type":         "SystemAssigned",
+						"identity_ids": []interface{}{},
+					},
+				},
+				IdentityConfigured: true,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s %v", v.Id.AzureResourceType, v.Input)
+		actual, err := renderRequestBody(v.Id, v.Input)
+		if v.ExpectError {
+			if err == nil {
+				t.Fatalf("Expected an error but got nil")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected no error but got %+v", err)
+		}
+		data, err := json.Marshal(actual.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != v.Expected {
+			t.Fatalf("Expected %s but got %s", v.Expected, data)
+		}
+		if len(actual.TagsAll) != len(v.TagsAll) {
+			t.Fatalf("Expected tags_all %v but got %v", v.TagsAll, actual.TagsAll)
+		}
+		for key, value := range v.TagsAll {
+			if actual.TagsAll[key] != value {
+				t.Fatalf("Expected tags_all %v but got %v", v.TagsAll, actual.TagsAll)
+			}
+		}
+		if actual.DefaultLocation != v.DefaultLocation {
+			t.Fatalf("Expected default location %q but got %q", v.DefaultLocation, actual.DefaultLocation)
+		}
+	}
+}
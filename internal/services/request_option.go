@@ -0,0 +1,96 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	operationCreate = "create"
+	operationRead   = "read"
+	operationUpdate = "update"
+	operationDelete = "delete"
+)
+
+// nonUpdateRequestOptionKeys are the request options which aren't used by the update request, changing only them
+// doesn't update the resource.
+var nonUpdateRequestOptionKeys = []string{
+	"create_headers",
+	"create_query_parameters",
+	"read_headers",
+	"read_query_parameters",
+	"delete_headers",
+	"delete_query_parameters",
+}
+
+func schemaRequestHeaders() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Optional:     true,
+		ValidateFunc: validate.RequestHeaders,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+func schemaRequestQueryParameters() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Optional:     true,
+		ValidateFunc: validate.RequestQueryParameters,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// requestOption returns the extra headers and query parameters of the operation, they're specified in `<operation>_headers`
+// and `<operation>_query_parameters`.
+func requestOption(d resourceGetter, operation string) clients.RequestOption {
+	return clients.RequestOption{
+		Headers:         expandStringMap(d.Get(fmt.Sprintf("%s_headers", operation))),
+		QueryParameters: expandStringMap(d.Get(fmt.Sprintf("%s_query_parameters", operation))),
+	}
+}
+
+func expandStringMap(input interface{}) map[string]string {
+	output := make(map[string]string)
+	if v, ok := input.(map[string]interface{}); ok {
+		for key, value := range v {
+			if value, ok := value.(string); ok {
+				output[key] = value
+			}
+		}
+	}
+	return output
+}
+
+// hasUpdateChanges returns whether the arguments other than the request options of the create, read and delete requests are changed.
+func hasUpdateChanges(d *schema.ResourceData) bool {
+	return d.HasChangesExcept(nonUpdateRequestOptionKeys...)
+}
+
+// hasUpdateChangedKeys works like hasUpdateChanges during plan. The keys changed by CustomizeDiff aren't included.
+func hasUpdateChangedKeys(d *schema.ResourceDiff) bool {
+	for _, key := range d.GetChangedKeysPrefix("") {
+		name, _, _ := strings.Cut(key, ".")
+		if !isNonUpdateRequestOptionKey(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNonUpdateRequestOptionKey(key string) bool {
+	for _, v := range nonUpdateRequestOptionKeys {
+		if v == key {
+			return true
+		}
+	}
+	return false
+}
@@ -1,44 +1,122 @@
 package services
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure"
 	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	azureutils "github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/services/rules"
 	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
-func schemaValidation(id parse.ResourceId, body interface{}) error {
+// schemaValidation validates the body against the embedded schema and then the cross-property rules, it returns one diagnostic for each error.
+// The rules don't depend on the embedded schema, so they're also checked when the resource type is unknown.
+// It also returns a warning if the api-version is a preview or it's behind the latest stable api-version by `outdatedThreshold` or more stable api-versions.
+// The `body` is a json string, so the diagnostics point to the `body` attribute and the paths of the invalid properties are in the summaries.
+func schemaValidation(id parse.ResourceId, body interface{}, ruleSet *rules.RuleSet, outdatedThreshold int) diag.Diagnostics {
 	log.Printf("[INFO] prepare validation for resource type: %s, api-version: %s", id.AzureResourceType, id.ApiVersion)
-	versions := azure.GetApiVersions(id.AzureResourceType)
-	if len(versions) == 0 {
-		return fmt.Errorf("the `type` is invalid, resource type %s can't be found", id.AzureResourceType)
+	var typeDiags diag.Diagnostics
+	if err := azure.ValidateResourceType(id.AzureResourceType, id.ApiVersion); err != nil {
+		typeDiags = diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Invalid `type`",
+				Detail:        strings.TrimSpace(err.Error()),
+				AttributePath: cty.GetAttrPath("type"),
+			},
+		}
+	}
+
+	body = utils.NormalizeObject(body)
+	errs, isSchemaErrors := bodyValidationErrors(id, body, ruleSet)
+	detailSuffix := ""
+	if source := azure.GetResourceDefinitionSource(id.AzureResourceType, id.ApiVersion); source != "" && isSchemaErrors {
+		detailSuffix = fmt.Sprintf(" (the schema of %s@%s is loaded from %s)", id.AzureResourceType, id.ApiVersion, source)
 	}
-	isVersionValid := false
-	for _, version := range versions {
-		if version == id.ApiVersion {
-			isVersionValid = true
-			break
+	var diags diag.Diagnostics
+	for _, err := range errs {
+		summary := "Invalid `body`"
+		var validationErr *azureutils.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Path != "" {
+			summary = fmt.Sprintf("Invalid `body`: `%s`", validationErr.Path)
 		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        strings.TrimSpace(err.Error()) + detailSuffix,
+			AttributePath: cty.GetAttrPath("body"),
+		})
 	}
-	if !isVersionValid {
-		return fmt.Errorf("the `type`'s api-version is invalid. The supported versions are [%s]\n", strings.Join(versions, ", "))
+	// the errors are sorted, because the properties are validated in the random order of the map
+	sort.SliceStable(diags, func(i, j int) bool {
+		return diags[i].Detail < diags[j].Detail
+	})
+	if typeDiags != nil {
+		return append(typeDiags, diags...)
 	}
+	return append(diags, apiVersionDiagnostics(id, body, outdatedThreshold)...)
+}
 
+// bodyValidationErrors validates the normalized body against the embedded schema, and then the cross-property rules if it's structurally valid.
+// It also returns whether the errors are found by the embedded schema.
+func bodyValidationErrors(id parse.ResourceId, body interface{}, ruleSet *rules.RuleSet) ([]error, bool) {
 	if id.ResourceDef != nil {
-		errors := (*id.ResourceDef).Validate(utils.NormalizeObject(body), "")
-		if len(errors) != 0 {
-			errorMsg := "the `body` is invalid: \n"
-			for _, err := range errors {
-				errorMsg += fmt.Sprintf("%s\n", err.Error())
-			}
-			return fmt.Errorf(errorMsg)
+		if errs := (*id.ResourceDef).Validate(body, ""); len(errs) != 0 {
+			return errs, true
 		}
 	}
-	return nil
+	// the cross-property rules assume that the body is structurally valid
+	return ruleSet.Validate(id.AzureResourceType, id.ApiVersion, body), false
+}
+
+// policyDiagnostics evaluates the policies against the body, it returns one diagnostic for each violation.
+func policyDiagnostics(policies *rules.PolicySet, id parse.ResourceId, body interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, violation := range policies.Evaluate(id.AzureResourceType, id.ApiVersion, utils.NormalizeObject(body)) {
+		severity := diag.Error
+		if violation.Severity == rules.SeverityWarning {
+			severity = diag.Warning
+		}
+		summary := "Policy violation"
+		var validationErr *azureutils.ValidationError
+		if errors.As(violation.Err, &validationErr) && validationErr.Path != "" {
+			summary = fmt.Sprintf("Policy violation: `%s`", validationErr.Path)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      severity,
+			Summary:       summary,
+			Detail:        violation.Err.Error(),
+			AttributePath: cty.GetAttrPath("body"),
+		})
+	}
+	return diags
+}
+
+// diagnosticsError joins the diagnostics to an error, it's used where only one error could be returned, like the CustomizeDiff.
+func diagnosticsError(diags diag.Diagnostics) error {
+	if !diags.HasError() {
+		return nil
+	}
+	messages := make([]string, 0, len(diags))
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			messages = append(messages, d.Detail)
+		}
+	}
+	if len(messages) == 1 {
+		return errors.New(messages[0])
+	}
+	return fmt.Errorf("%d errors are found:\n%s", len(messages), strings.Join(messages, "\n"))
 }
 
 func isResourceHasProperty(resourceDef *types.ResourceType, property string) bool {
@@ -56,3 +134,31 @@ func isResourceHasProperty(resourceDef *types.ResourceType, property string) boo
 	}
 	return false
 }
+
+func defaultParent(client *clients.Client) parse.DefaultParent {
+	return parse.DefaultParent{
+		SubscriptionId:    client.SubscriptionId,
+		ParentId:          client.Features.DefaultParentId,
+		ResourceGroupName: client.Features.DefaultResourceGroupName,
+	}
+}
+
+// flattenOutput returns the json which contains the properties in the response body specified by the paths.
+func flattenOutput(responseBody interface{}, paths []interface{}) string {
+	var output interface{}
+	if len(paths) != 0 {
+		output = make(map[string]interface{})
+		for _, path := range paths {
+			part := utils.ExtractObject(responseBody, path.(string))
+			if part == nil {
+				continue
+			}
+			output = utils.GetMergedJson(output, part)
+		}
+	}
+	if output == nil {
+		output = make(map[string]interface{})
+	}
+	outputJson, _ := json.Marshal(output)
+	return string(outputJson)
+}
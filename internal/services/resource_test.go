@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/features"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/services/rules"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func Test_SchemaValidationRules(t *testing.T) {
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Body         string
+		Summaries    []string
+	}{
+		{
+			ResourceType: "Microsoft.ManagedIdentity/userAssignedIdentities",
+			ApiVersion:   "2018-11-30",
+			Body:         `{"location": "westeurope", "identity": {"type": "UserAssigned"}}`,
+			Summaries:    []string{"Invalid `body`: `identity`"},
+		},
+		{
+			// the rules are checked when the resource type is unknown
+			ResourceType: "Microsoft.Foo/bars",
+			ApiVersion:   "2021-01-01",
+			Body:         `{"identity": {"type": "UserAssigned"}}`,
+			Summaries:    []string{"Invalid `type`", "Invalid `body`: `identity.userAssignedIdentities`"},
+		},
+		{
+			ResourceType: "Microsoft.Foo/bars",
+			ApiVersion:   "2021-01-01",
+			Body:         `{"identity": {"type": "SystemAssigned", "userAssignedIdentities": {"id1": {}}}}`,
+			Summaries:    []string{"Invalid `type`", "Invalid `body`: `identity.type`"},
+		},
+	}
+
+	ruleSet := rules.NewDefaultRuleSet()
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s %s", v.ResourceType, v.ApiVersion, v.Body)
+		var body interface{}
+		if err := json.Unmarshal([]byte(v.Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		id := parse.ResourceId{
+			AzureResourceType: v.ResourceType,
+			ApiVersion:        v.ApiVersion,
+		}
+		id.ResourceDef, _ = azure.GetResourceDefinition(v.ResourceType, v.ApiVersion)
+		summaries := make([]string, 0)
+		for _, d := range schemaValidation(id, body, ruleSet, features.DefaultOutdatedApiVersionThreshold) {
+			summaries = append(summaries, d.Summary)
+		}
+		if strings.Join(summaries, ",") != strings.Join(v.Summaries, ",") {
+			t.Fatalf("Expected %q but got %q", v.Summaries, summaries)
+		}
+	}
+}
+
+func Test_ResourceTypeChangeRequiresNew(t *testing.T) {
+	testData := []struct {
+		Type        string
+		RequiresNew bool
+	}{
+		{
+			// only the api-version is changed
+			Type:        "Microsoft.Network/virtualNetworks@2021-03-01",
+			RequiresNew: false,
+		},
+		{
+			Type:        "Microsoft.Network/networkSecurityGroups@2021-02-01",
+			RequiresNew: true,
+		},
+		{
+			// the new type is unknown, the resource type may be changed
+			Type:        hcl2shimUnknownValue,
+			RequiresNew: true,
+		},
+	}
+
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet1"
+	state := &terraform.InstanceState{
+		ID: id,
+		Attributes: map[string]string{
+			"id":                        id,
+			"name":                      "vnet1",
+			"parent_id":                 "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			"type":                      "Microsoft.Network/virtualNetworks@2021-02-01",
+			"body":                      "{}",
+			"schema_validation_enabled": "false",
+		},
+	}
+	// the raw config isn't set by SimpleDiff, so the parent id is built from the default resource group
+	userFeatures := features.Default()
+	userFeatures.DefaultResourceGroupName = "rg"
+	client := &clients.Client{
+		SubscriptionId: "00000000-0000-0000-0000-000000000000",
+		Features:       userFeatures,
+	}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Type)
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                      "vnet1",
+			"parent_id":                 "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			"type":                      v.Type,
+			"body":                      "{}",
+			"schema_validation_enabled": false,
+		})
+		diff, err := ResourceAzureGenericResource().SimpleDiff(context.Background(), state, config, client)
+		if err != nil {
+			t.Fatalf("Expect a diff but got an error: %s", err)
+		}
+		if diff == nil || diff.RequiresNew() != v.RequiresNew {
+			t.Fatalf("Expected RequiresNew %t but got %+v", v.RequiresNew, diff)
+		}
+	}
+}
+
+// hcl2shimUnknownValue is the value of the unknown attributes in the legacy config.
+const hcl2shimUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+	"github.com/google/cel-go/cel"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// ruleFile is the content of a rule file, it's written in HCL or JSON, for example:
+//
+//	rule "storage_https_only" {
+//	  resource_type   = "Microsoft.Storage/storageAccounts"
+//	  min_api_version = "2019-06-01"
+//	  condition       = "!has(body.properties.supportsHttpsTrafficOnly) || body.properties.supportsHttpsTrafficOnly"
+//	  path            = "properties.supportsHttpsTrafficOnly"
+//	  message         = "only the https traffic is allowed"
+//	}
+type ruleFile struct {
+	Rules []ruleBlock `hcl:"rule,block"`
+}
+
+type ruleBlock struct {
+	Name          string `hcl:"name,label"`
+	ResourceType  string `hcl:"resource_type"`
+	MinApiVersion string `hcl:"min_api_version,optional"`
+	MaxApiVersion string `hcl:"max_api_version,optional"`
+	// Condition is a CEL expression which returns true if the body is valid, the body is in the variable `body`
+	Condition string `hcl:"condition"`
+	// Path is the path of the property which the error points to, it's the body itself if it's empty
+	Path    string `hcl:"path,optional"`
+	Message string `hcl:"message"`
+}
+
+// loadRuleFile parses the rule file and compiles the conditions, the file extension must be `.hcl` or `.json`.
+func loadRuleFile(path string) ([]Rule, error) {
+	var file ruleFile
+	if err := hclsimple.DecodeFile(path, nil, &file); err != nil {
+		return nil, err
+	}
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]Rule, 0, len(file.Rules))
+	for _, block := range file.Rules {
+		check, err := conditionCheck(env, block.Name, block.Condition, block.Path, block.Message, utils.ErrorRule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %+v", block.Name, err)
+		}
+		rules = append(rules, Rule{
+			Name:          block.Name,
+			ResourceType:  block.ResourceType,
+			MinApiVersion: block.MinApiVersion,
+			MaxApiVersion: block.MaxApiVersion,
+			Check:         check,
+		})
+	}
+	return rules, nil
+}
+
+// newEnv returns the CEL environment of the conditions, the body is in the variable `body`.
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(cel.Variable("body", cel.DynType))
+}
+
+// conditionCheck compiles the condition to a check, the check returns an error created by newError if the condition returns false.
+func conditionCheck(env *cel.Env, name, condition, path, message string, newError func(key, name, message string) error) (func(body map[string]interface{}) []error, error) {
+	ast, issues := env.Compile(condition)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid condition: %+v", issues.Err())
+	}
+	if outputType := ast.OutputType(); !outputType.IsExactType(cel.BoolType) && !outputType.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("invalid condition: expect the condition to return `bool` but got `%s`", outputType)
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return func(body map[string]interface{}) []error {
+		out, _, err := program.Eval(map[string]interface{}{"body": body})
+		if err != nil {
+			// the condition usually fails to evaluate because the properties it refers to are missing
+			log.Printf("[WARN] skipping %q, failed to evaluate the condition: %+v", name, err)
+			return nil
+		}
+		if valid, ok := out.Value().(bool); ok && !valid {
+			return []error{newError(path, name, message)}
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,157 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// Severity is the severity of the policy violations.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Policy is a rule configured by the users, its violations are reported with its severity.
+type Policy struct {
+	Rule
+	Severity Severity
+}
+
+// Violation is an error of a policy.
+type Violation struct {
+	Severity Severity
+	Err      error
+}
+
+// policyFile is the content of a policy file, it's written in HCL or JSON, for example:
+//
+//	policy "deny_public_network_access" {
+//	  resource_type = "*"
+//	  severity      = "error"
+//	  condition     = "!has(body.properties.publicNetworkAccess) || body.properties.publicNetworkAccess == 'Disabled'"
+//	  path          = "properties.publicNetworkAccess"
+//	  message       = "the public network access must be disabled"
+//	}
+type policyFile struct {
+	Policies []policyBlock `hcl:"policy,block"`
+}
+
+type policyBlock struct {
+	Name          string `hcl:"name,label"`
+	ResourceType  string `hcl:"resource_type"`
+	MinApiVersion string `hcl:"min_api_version,optional"`
+	MaxApiVersion string `hcl:"max_api_version,optional"`
+	// Severity is `error` or `warning`, it defaults to `error`
+	Severity  string `hcl:"severity,optional"`
+	Condition string `hcl:"condition"`
+	Path      string `hcl:"path,optional"`
+	Message   string `hcl:"message"`
+}
+
+// PolicySet is the policies loaded from the policy files, it's immutable after it's loaded.
+type PolicySet struct {
+	policies []Policy
+}
+
+// LoadPolicies loads the policies in the files, the directories are expanded to the `.hcl` and `.json` files in them.
+func LoadPolicies(paths []string) (*PolicySet, error) {
+	files := make([]string, 0)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".hcl" || ext == ".json") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	set := &PolicySet{}
+	for _, file := range files {
+		if filepath.Ext(file) == ".rego" {
+			return nil, fmt.Errorf("failed to load the policies in %s: the OPA/Rego policies are not supported, the policies must be HCL or JSON files with CEL conditions", file)
+		}
+		var content policyFile
+		if err := hclsimple.DecodeFile(file, nil, &content); err != nil {
+			return nil, fmt.Errorf("failed to load the policies in %s: %+v", file, err)
+		}
+		for _, block := range content.Policies {
+			severity := Severity(strings.ToLower(block.Severity))
+			switch severity {
+			case "":
+				severity = SeverityError
+			case SeverityError, SeverityWarning:
+			default:
+				return nil, fmt.Errorf("failed to load the policies in %s: policy %q: invalid severity %q, expect `error` or `warning`", file, block.Name, block.Severity)
+			}
+			check, err := conditionCheck(env, block.Name, block.Condition, block.Path, block.Message, utils.ErrorPolicy)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load the policies in %s: policy %q: %+v", file, block.Name, err)
+			}
+			set.policies = append(set.policies, Policy{
+				Rule: Rule{
+					Name:          block.Name,
+					ResourceType:  block.ResourceType,
+					MinApiVersion: block.MinApiVersion,
+					MaxApiVersion: block.MaxApiVersion,
+					Check:         check,
+				},
+				Severity: severity,
+			})
+		}
+	}
+	return set, nil
+}
+
+// Len returns the number of the policies.
+func (s *PolicySet) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.policies)
+}
+
+// Evaluate checks the body against the policies which apply to the resource type and the api-version,
+// the violations are in the order of the loaded policies.
+func (s *PolicySet) Evaluate(resourceType, apiVersion string, body interface{}) []Violation {
+	bodyMap, ok := body.(map[string]interface{})
+	if s == nil || !ok {
+		return nil
+	}
+	violations := make([]Violation, 0)
+	for _, policy := range s.policies {
+		if !policy.AppliesTo(resourceType, apiVersion) {
+			continue
+		}
+		for _, err := range policy.Check(bodyMap) {
+			violations = append(violations, Violation{
+				Severity: policy.Severity,
+				Err:      err,
+			})
+		}
+	}
+	return violations
+}
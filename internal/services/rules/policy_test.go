@@ -0,0 +1,147 @@
+package rules_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/services/rules"
+)
+
+const policyFile = `
+policy "deny_public_network_access" {
+  resource_type = "*"
+  condition     = "!has(body.properties.publicNetworkAccess) || body.properties.publicNetworkAccess == 'Disabled'"
+  path          = "properties.publicNetworkAccess"
+  message       = "the public network access must be disabled"
+}
+
+policy "standard_sku" {
+  resource_type   = "Microsoft.ServiceBus/namespaces"
+  max_api_version = "2021-06-01-preview"
+  severity        = "warning"
+  condition       = "body.sku.name != 'Premium'"
+  path            = "sku.name"
+  message         = "the premium sku is not recommended"
+}
+`
+
+const policyJsonFile = `{
+  "policy": {
+    "owner_tag": {
+      "resource_type": "*",
+      "severity": "WARNING",
+      "condition": "has(body.tags) && 'owner' in body.tags",
+      "path": "tags",
+      "message": "the owner tag is required"
+    }
+  }
+}`
+
+func Test_LoadPolicies(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"network.hcl": policyFile,
+		"tags.json":   policyJsonFile,
+		"README.md":   "the other files are ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	policies, err := rules.LoadPolicies([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policies.Len() != 3 {
+		t.Fatalf("Expected %d policies but got %d", 3, policies.Len())
+	}
+
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Body         string
+		Severities   []rules.Severity
+	}{
+		{
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			ApiVersion:   "2021-06-01-preview",
+			Body:         `{"sku": {"name": "Premium"}, "properties": {"publicNetworkAccess": "Enabled"}, "tags": {"owner": "me"}}`,
+			Severities:   []rules.Severity{rules.SeverityError, rules.SeverityWarning},
+		},
+		{
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			ApiVersion:   "2022-01-01-preview",
+			Body:         `{"sku": {"name": "Premium"}, "properties": {"publicNetworkAccess": "Disabled"}, "tags": {"owner": "me"}}`,
+			Severities:   []rules.Severity{},
+		},
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			ApiVersion:   "2021-01-01",
+			Body:         `{"sku": {"name": "Premium"}, "properties": {}, "tags": {}}`,
+			Severities:   []rules.Severity{rules.SeverityWarning},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s %s", v.ResourceType, v.ApiVersion, v.Body)
+		var body interface{}
+		if err := json.Unmarshal([]byte(v.Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		violations := policies.Evaluate(v.ResourceType, v.ApiVersion, body)
+		if len(violations) != len(v.Severities) {
+			t.Fatalf("Expected %d violations but got %v", len(v.Severities), violations)
+		}
+		for i, violation := range violations {
+			if violation.Severity != v.Severities[i] {
+				t.Fatalf("Expected severity %q but got %q: %v", v.Severities[i], violation.Severity, violation.Err)
+			}
+		}
+	}
+}
+
+func Test_LoadInvalidPolicies(t *testing.T) {
+	testData := []struct {
+		Name    string
+		Content string
+	}{
+		{
+			Name: "severity.hcl",
+			Content: `policy "invalid_severity" {
+  resource_type = "*"
+  severity      = "critical"
+  condition     = "true"
+  message       = "message"
+}`,
+		},
+		{
+			Name: "condition.hcl",
+			Content: `policy "invalid_condition" {
+  resource_type = "*"
+  condition     = "body.sku.name =="
+  message       = "message"
+}`,
+		},
+		{
+			Name:    "policy.rego",
+			Content: `package azapi`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v.Name)
+		path := filepath.Join(t.TempDir(), v.Name)
+		if err := os.WriteFile(path, []byte(v.Content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rules.LoadPolicies([]string{path}); err == nil {
+			t.Fatalf("Expected an error but got nil")
+		}
+	}
+	if _, err := rules.LoadPolicies([]string{filepath.Join(t.TempDir(), "missing.hcl")}); err == nil {
+		t.Fatalf("Expected an error for the missing file but got nil")
+	}
+}
@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+)
+
+// PathEnvName is the environment variable of the path of the rule file.
+const PathEnvName = "ARM_VALIDATION_RULES_PATH"
+
+// Rule is a cross-property rule of the request bodies, it checks the constraints which can't be described by the schemas,
+// like the identity type requires the user assigned identities.
+type Rule struct {
+	// Name identifies the rule in the error messages
+	Name string
+	// ResourceType is the resource type which the rule applies to, it's case-insensitive and `*` matches all the resource types
+	ResourceType string
+	// MinApiVersion is the inclusive lower bound of the api-versions which the rule applies to, it's unbounded if it's empty
+	MinApiVersion string
+	// MaxApiVersion is the inclusive upper bound of the api-versions which the rule applies to, it's unbounded if it's empty
+	MaxApiVersion string
+	// Check returns the violations of the body, the errors should be created by `utils.ErrorRule`
+	Check func(body map[string]interface{}) []error
+}
+
+// AppliesTo returns whether the rule applies to the resource type and the api-version.
+func (r Rule) AppliesTo(resourceType, apiVersion string) bool {
+	if r.ResourceType != "*" && !strings.EqualFold(r.ResourceType, resourceType) {
+		return false
+	}
+	if r.MinApiVersion != "" && azure.CompareApiVersions(apiVersion, r.MinApiVersion) < 0 {
+		return false
+	}
+	if r.MaxApiVersion != "" && azure.CompareApiVersions(apiVersion, r.MaxApiVersion) > 0 {
+		return false
+	}
+	return true
+}
+
+// RuleSet is a concurrency-safe set of the rules.
+type RuleSet struct {
+	mutex sync.RWMutex
+	rules []Rule
+	// loaded contains the absolute paths of the loaded rule files
+	loaded map[string]bool
+}
+
+func NewRuleSet(rules ...Rule) *RuleSet {
+	return &RuleSet{
+		rules:  rules,
+		loaded: make(map[string]bool),
+	}
+}
+
+// Register adds the rules to the rule set.
+func (s *RuleSet) Register(rules ...Rule) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rules = append(s.rules, rules...)
+}
+
+// LoadFile registers the rules in the rule file, it returns the number of the registered rules.
+// Loading the same path more than once is a no-op.
+func (s *RuleSet) LoadFile(path string) (int, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	s.mutex.RLock()
+	loaded := s.loaded[absPath]
+	s.mutex.RUnlock()
+	if loaded {
+		return 0, nil
+	}
+
+	rules, err := loadRuleFile(absPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load the rules in %s: %+v", path, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.loaded[absPath] {
+		return 0, nil
+	}
+	s.rules = append(s.rules, rules...)
+	s.loaded[absPath] = true
+	log.Printf("[INFO] loaded %d rules in %s", len(rules), absPath)
+	return len(rules), nil
+}
+
+// Validate checks the body against the rules which apply to the resource type and the api-version,
+// the errors are in the order of the registered rules.
+func (s *RuleSet) Validate(resourceType, apiVersion string, body interface{}) []error {
+	bodyMap, ok := body.(map[string]interface{})
+	if s == nil || !ok {
+		return nil
+	}
+	s.mutex.RLock()
+	rules := s.rules
+	s.mutex.RUnlock()
+
+	errors := make([]error, 0)
+	for _, rule := range rules {
+		if rule.Check != nil && rule.AppliesTo(resourceType, apiVersion) {
+			errors = append(errors, rule.Check(bodyMap)...)
+		}
+	}
+	return errors
+}
+
+// NewDefaultRuleSet returns a rule set which contains the starter rules, the rules in the rule files could be loaded into it by LoadFile.
+func NewDefaultRuleSet() *RuleSet {
+	return NewRuleSet(starterRules()...)
+}
@@ -0,0 +1,250 @@
+package rules_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+	"github.com/Azure/terraform-provider-azapi/internal/services/rules"
+)
+
+func Test_StarterRules(t *testing.T) {
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Body         string
+		Paths        []string
+	}{
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			ApiVersion:   "2021-01-01",
+			Body:         `{"identity": {"type": "UserAssigned"}}`,
+			Paths:        []string{"identity.userAssignedIdentities"},
+		},
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			ApiVersion:   "2021-01-01",
+			Body:         `{"identity": {"type": "SystemAssigned, UserAssigned", "userAssignedIdentities": {"id": {}}}}`,
+			Paths:        []string{},
+		},
+		{
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			ApiVersion:   "2021-01-01",
+			Body:         `{"identity": {"type": "SystemAssigned", "userAssignedIdentities": {"id": {}}}}`,
+			Paths:        []string{"identity.type"},
+		},
+		{
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			ApiVersion:   "2021-06-01-preview",
+			Body:         `{"sku": {"name": "Premium", "tier": "Standard"}}`,
+			Paths:        []string{"sku.tier"},
+		},
+		{
+			ResourceType: "microsoft.servicebus/namespaces",
+			ApiVersion:   "2021-06-01-preview",
+			Body:         `{"sku": {"name": "Premium", "tier": "premium"}}`,
+			Paths:        []string{},
+		},
+		{
+			ResourceType: "Microsoft.Web/serverfarms",
+			ApiVersion:   "2021-02-01",
+			Body:         `{"sku": {"name": "P1v3", "tier": "PremiumV2"}}`,
+			Paths:        []string{"sku.tier"},
+		},
+		{
+			// the unknown sku names are not checked
+			ResourceType: "Microsoft.Web/serverfarms",
+			ApiVersion:   "2021-02-01",
+			Body:         `{"sku": {"name": "WS1", "tier": "WorkflowStandard"}}`,
+			Paths:        []string{},
+		},
+		{
+			ResourceType: "Microsoft.Cache/redis",
+			ApiVersion:   "2020-06-01",
+			Body:         `{"properties": {"sku": {"name": "Premium", "family": "C", "capacity": 1}}}`,
+			Paths:        []string{"properties.sku.family"},
+		},
+		{
+			ResourceType: "Microsoft.Network/dnsZones",
+			ApiVersion:   "2018-05-01",
+			Body:         `{"location": "westeurope"}`,
+			Paths:        []string{"location"},
+		},
+		{
+			ResourceType: "Microsoft.Network/dnsZones",
+			ApiVersion:   "2018-05-01",
+			Body:         `{"location": "Global"}`,
+			Paths:        []string{},
+		},
+	}
+
+	ruleSet := rules.NewDefaultRuleSet()
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s %s", v.ResourceType, v.ApiVersion, v.Body)
+		var body interface{}
+		if err := json.Unmarshal([]byte(v.Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		actual := errorPaths(t, ruleSet.Validate(v.ResourceType, v.ApiVersion, body))
+		if strings.Join(actual, ",") != strings.Join(v.Paths, ",") {
+			t.Fatalf("Expected %q but got %q", v.Paths, actual)
+		}
+	}
+}
+
+func Test_RuleAppliesTo(t *testing.T) {
+	rule := rules.Rule{
+		ResourceType:  "Microsoft.Test/foos",
+		MinApiVersion: "2021-01-01",
+		MaxApiVersion: "2022-01-01-preview",
+	}
+	testData := []struct {
+		ResourceType string
+		ApiVersion   string
+		Expected     bool
+	}{
+		{ResourceType: "Microsoft.Test/foos", ApiVersion: "2021-01-01", Expected: true},
+		{ResourceType: "microsoft.test/FOOS", ApiVersion: "2021-06-01-preview", Expected: true},
+		{ResourceType: "Microsoft.Test/foos", ApiVersion: "2022-01-01-preview", Expected: true},
+		{ResourceType: "Microsoft.Test/foos", ApiVersion: "2021-01-01-preview", Expected: false},
+		{ResourceType: "Microsoft.Test/foos", ApiVersion: "2022-01-01", Expected: false},
+		{ResourceType: "Microsoft.Test/bars", ApiVersion: "2021-06-01", Expected: false},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s@%s", v.ResourceType, v.ApiVersion)
+		if actual := rule.AppliesTo(v.ResourceType, v.ApiVersion); actual != v.Expected {
+			t.Fatalf("Expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+const ruleFile = `
+rule "storage_https_only" {
+  resource_type   = "Microsoft.Storage/storageAccounts"
+  min_api_version = "2019-06-01"
+  condition       = "!has(body.properties.supportsHttpsTrafficOnly) || body.properties.supportsHttpsTrafficOnly"
+  path            = "properties.supportsHttpsTrafficOnly"
+  message         = "only the https traffic is allowed"
+}
+
+rule "tags_owner" {
+  resource_type = "*"
+  condition     = "has(body.tags) && 'owner' in body.tags"
+  path          = "tags"
+  message       = "the owner tag is required"
+}
+`
+
+func Test_LoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.hcl")
+	if err := os.WriteFile(path, []byte(ruleFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ruleSet := rules.NewRuleSet()
+	count, err := ruleSet.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("Expected %d rules but got %d", 2, count)
+	}
+	// the same path is loaded only once
+	if count, err := ruleSet.LoadFile(path); err != nil || count != 0 {
+		t.Fatalf("Expected no rules to be loaded again but got %d, %v", count, err)
+	}
+
+	testData := []struct {
+		ApiVersion string
+		Body       string
+		Paths      []string
+	}{
+		{
+			ApiVersion: "2021-01-01",
+			Body:       `{"properties": {"supportsHttpsTrafficOnly": false}, "tags": {"owner": "me"}}`,
+			Paths:      []string{"properties.supportsHttpsTrafficOnly"},
+		},
+		{
+			ApiVersion: "2019-04-01",
+			Body:       `{"properties": {"supportsHttpsTrafficOnly": false}, "tags": {"owner": "me"}}`,
+			Paths:      []string{},
+		},
+		{
+			ApiVersion: "2021-01-01",
+			Body:       `{"properties": {}, "tags": {}}`,
+			Paths:      []string{"tags"},
+		},
+		{
+			// the rules whose conditions fail to evaluate are skipped
+			ApiVersion: "2021-01-01",
+			Body:       `{"tags": {"owner": "me"}}`,
+			Paths:      []string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s %s", v.ApiVersion, v.Body)
+		var body interface{}
+		if err := json.Unmarshal([]byte(v.Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		actual := errorPaths(t, ruleSet.Validate("Microsoft.Storage/storageAccounts", v.ApiVersion, body))
+		if strings.Join(actual, ",") != strings.Join(v.Paths, ",") {
+			t.Fatalf("Expected %q but got %q", v.Paths, actual)
+		}
+	}
+	// the loaded rules don't apply to the other rule sets
+	var body interface{}
+	if err := json.Unmarshal([]byte(testData[0].Body), &body); err != nil {
+		t.Fatal(err)
+	}
+	if errs := rules.NewDefaultRuleSet().Validate("Microsoft.Storage/storageAccounts", testData[0].ApiVersion, body); len(errs) != 0 {
+		t.Fatalf("Expected no errors but got %v", errs)
+	}
+}
+
+func Test_LoadInvalidFile(t *testing.T) {
+	testData := []string{
+		`rule "missing_condition" {
+  resource_type = "*"
+  message       = "message"
+}`,
+		`rule "invalid_condition" {
+  resource_type = "*"
+  condition     = "has(body.tags) &&"
+  message       = "message"
+}`,
+		`rule "not_bool" {
+  resource_type = "*"
+  condition     = "'value'"
+  message       = "message"
+}`,
+	}
+
+	for index, v := range testData {
+		t.Logf("[DEBUG] Testing Value %s", v)
+		path := filepath.Join(t.TempDir(), "rules.hcl")
+		if err := os.WriteFile(path, []byte(v), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rules.NewRuleSet().LoadFile(path); err == nil {
+			t.Fatalf("Expected an error for case %d but got nil", index)
+		}
+	}
+}
+
+func errorPaths(t *testing.T, errs []error) []string {
+	paths := make([]string, 0)
+	for _, err := range errs {
+		var validationErr *utils.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Kind != utils.ValidationErrorRule {
+			t.Fatalf("Expected a rule violation but got %v", err)
+		}
+		paths = append(paths, validationErr.Path)
+	}
+	return paths
+}
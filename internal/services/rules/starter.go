@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/location"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
+)
+
+// globalResourceTypes are the resource types whose location must be `global`.
+var globalResourceTypes = []string{
+	"Microsoft.Network/dnsZones",
+	"Microsoft.Network/privateDnsZones",
+	"Microsoft.Network/trafficManagerProfiles",
+	"Microsoft.Network/frontDoors",
+	"Microsoft.Network/FrontDoorWebApplicationFirewallPolicies",
+}
+
+// appServicePlanTiers maps the well-known sku names of the app service plans to their tiers.
+var appServicePlanTiers = map[string]string{
+	"f1":   "Free",
+	"d1":   "Shared",
+	"b1":   "Basic",
+	"b2":   "Basic",
+	"b3":   "Basic",
+	"s1":   "Standard",
+	"s2":   "Standard",
+	"s3":   "Standard",
+	"p1v2": "PremiumV2",
+	"p2v2": "PremiumV2",
+	"p3v2": "PremiumV2",
+	"p0v3": "PremiumV3",
+	"p1v3": "PremiumV3",
+	"p2v3": "PremiumV3",
+	"p3v3": "PremiumV3",
+	"y1":   "Dynamic",
+	"ep1":  "ElasticPremium",
+	"ep2":  "ElasticPremium",
+	"ep3":  "ElasticPremium",
+}
+
+func starterRules() []Rule {
+	rules := []Rule{
+		{
+			Name:         "identity_user_assigned",
+			ResourceType: "*",
+			Check:        checkUserAssignedIdentities,
+		},
+		{
+			Name:         "servicebus_sku_tier",
+			ResourceType: "Microsoft.ServiceBus/namespaces",
+			Check:        skuTierCheck("servicebus_sku_tier", "sku", sameTier),
+		},
+		{
+			Name:         "eventhub_sku_tier",
+			ResourceType: "Microsoft.EventHub/namespaces",
+			Check:        skuTierCheck("eventhub_sku_tier", "sku", sameTier),
+		},
+		{
+			Name:         "app_service_plan_sku_tier",
+			ResourceType: "Microsoft.Web/serverfarms",
+			Check: skuTierCheck("app_service_plan_sku_tier", "sku", func(name string) string {
+				return appServicePlanTiers[strings.ToLower(name)]
+			}),
+		},
+		{
+			Name:         "redis_sku_family",
+			ResourceType: "Microsoft.Cache/redis",
+			Check:        checkRedisSkuFamily,
+		},
+	}
+	for _, resourceType := range globalResourceTypes {
+		rules = append(rules, Rule{
+			Name:         "global_location",
+			ResourceType: resourceType,
+			Check:        globalLocationCheck(resourceType),
+		})
+	}
+	return rules
+}
+
+// checkUserAssignedIdentities checks that the user assigned identities are specified if and only if the identity type includes `UserAssigned`.
+func checkUserAssignedIdentities(body map[string]interface{}) []error {
+	identity, ok := body["identity"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	identityType, ok := identity["type"].(string)
+	if !ok {
+		return nil
+	}
+	userAssigned := strings.Contains(strings.ToLower(identityType), "userassigned")
+	identities, _ := identity["userAssignedIdentities"].(map[string]interface{})
+	switch {
+	case userAssigned && len(identities) == 0:
+		return []error{utils.ErrorRule("identity.userAssignedIdentities", "identity_user_assigned",
+			fmt.Sprintf("`identity.userAssignedIdentities` must be specified when `identity.type` is `%s`", identityType))}
+	case !userAssigned && len(identities) != 0:
+		return []error{utils.ErrorRule("identity.type", "identity_user_assigned",
+			"`identity.userAssignedIdentities` can only be specified when `identity.type` includes `UserAssigned`")}
+	}
+	return nil
+}
+
+// skuTierCheck returns a check that the sku's tier matches its name, the tier function returns an empty string for the unknown names.
+func skuTierCheck(rule string, path string, tier func(name string) string) func(body map[string]interface{}) []error {
+	return func(body map[string]interface{}) []error {
+		sku, ok := body[path].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		name, nameOk := sku["name"].(string)
+		actual, tierOk := sku["tier"].(string)
+		if !nameOk || !tierOk {
+			return nil
+		}
+		if expected := tier(name); expected != "" && !strings.EqualFold(expected, actual) {
+			return []error{utils.ErrorRule(path+".tier", rule, fmt.Sprintf("`%[1]s.tier` must be `%[2]s` when `%[1]s.name` is `%[3]s`", path, expected, name))}
+		}
+		return nil
+	}
+}
+
+func sameTier(name string) string {
+	return name
+}
+
+// checkRedisSkuFamily checks that the premium caches use the `P` family and the others use the `C` family.
+func checkRedisSkuFamily(body map[string]interface{}) []error {
+	properties, ok := body["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	sku, ok := properties["sku"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	name, nameOk := sku["name"].(string)
+	family, familyOk := sku["family"].(string)
+	if !nameOk || !familyOk {
+		return nil
+	}
+	expected := "C"
+	if strings.EqualFold(name, "Premium") {
+		expected = "P"
+	}
+	if !strings.EqualFold(family, expected) {
+		return []error{utils.ErrorRule("properties.sku.family", "redis_sku_family",
+			fmt.Sprintf("`properties.sku.family` must be `%s` when `properties.sku.name` is `%s`", expected, name))}
+	}
+	return nil
+}
+
+func globalLocationCheck(resourceType string) func(body map[string]interface{}) []error {
+	return func(body map[string]interface{}) []error {
+		value, ok := body["location"].(string)
+		if !ok || location.Normalize(value) == "global" {
+			return nil
+		}
+		return []error{utils.ErrorRule("location", "global_location", fmt.Sprintf("`location` must be `global` for `%s`, but got `%s`", resourceType, value))}
+	}
+}
@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RequestHeaders validates the extra headers of a request, the `Authorization`, `Content-Type`, `Accept` and `Host` headers
+// are set by the provider or the http client and can't be overridden.
+func RequestHeaders(input interface{}, key string) (warnings []string, errors []error) {
+	return requestOption(input, key, "header", "Authorization", "Content-Type", "Accept", "Host")
+}
+
+// RequestQueryParameters validates the extra query parameters of a request, the `api-version` is specified in `type` and can't be overridden.
+func RequestQueryParameters(input interface{}, key string) (warnings []string, errors []error) {
+	return requestOption(input, key, "query parameter", "api-version")
+}
+
+func requestOption(input interface{}, key string, kind string, reserved ...string) (warnings []string, errors []error) {
+	v, ok := input.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a map", key))
+		return
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			errors = append(errors, fmt.Errorf("expected the %s names in %q not to be empty", kind, key))
+			continue
+		}
+		for _, v := range reserved {
+			if strings.EqualFold(name, v) {
+				errors = append(errors, fmt.Errorf("the %s %q in %q can't be overridden", kind, name, key))
+			}
+		}
+	}
+
+	return
+}
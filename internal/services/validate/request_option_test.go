@@ -0,0 +1,99 @@
+package validate
+
+import "testing"
+
+func TestRequestHeaders(t *testing.T) {
+	cases := []struct {
+		Input map[string]interface{}
+		Valid bool
+	}{
+		{
+			// empty
+			Input: map[string]interface{}{},
+			Valid: true,
+		},
+
+		{
+			Input: map[string]interface{}{"If-Match": "*", "x-ms-client-request-id": "00000000-0000-0000-0000-000000000000"},
+			Valid: true,
+		},
+
+		{
+			Input: map[string]interface{}{"Authorization": "Bearer token"},
+			Valid: false,
+		},
+
+		{
+			// lower-cased
+			Input: map[string]interface{}{"authorization": "Bearer token"},
+			Valid: false,
+		},
+
+		{
+			Input: map[string]interface{}{"content-type": "application/xml"},
+			Valid: false,
+		},
+
+		{
+			Input: map[string]interface{}{"Accept": "application/xml"},
+			Valid: false,
+		},
+
+		{
+			Input: map[string]interface{}{"Host": "example.com"},
+			Valid: false,
+		},
+
+		{
+			Input: map[string]interface{}{"": "value"},
+			Valid: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %v", tc.Input)
+		_, errors := RequestHeaders(tc.Input, "test")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t", tc.Valid, valid)
+		}
+	}
+}
+
+func TestRequestQueryParameters(t *testing.T) {
+	cases := []struct {
+		Input map[string]interface{}
+		Valid bool
+	}{
+		{
+			Input: map[string]interface{}{"$expand": "properties", "includeDeleted": "true"},
+			Valid: true,
+		},
+
+		{
+			Input: map[string]interface{}{"api-version": "2021-09-01"},
+			Valid: false,
+		},
+
+		{
+			// upper-cased
+			Input: map[string]interface{}{"API-VERSION": "2021-09-01"},
+			Valid: false,
+		},
+
+		{
+			// the headers are validated separately
+			Input: map[string]interface{}{"Authorization": "Bearer token"},
+			Valid: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %v", tc.Input)
+		_, errors := RequestQueryParameters(tc.Input, "test")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t", tc.Valid, valid)
+		}
+	}
+}
@@ -3,9 +3,9 @@ package validate
 import (
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
 )
 
@@ -52,8 +52,7 @@ func ResourceType(i interface{}, k string) ([]string, []error) {
 		return nil, []error{fmt.Errorf("expected %q to not be an empty string, got %v", k, i)}
 	}
 
-	parts := strings.Split(v, "@")
-	if len(parts) != 2 {
+	if _, _, ok := utils.SplitResourceType(v); !ok {
 		return nil, []error{fmt.Errorf("expected %q to be <resource-type>@<api-version>", k)}
 	}
 
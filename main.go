@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"github.com/Azure/terraform-provider-azapi/internal/cmd"
 	"github.com/Azure/terraform-provider-azapi/internal/provider"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
@@ -13,6 +15,11 @@ func main() {
 	// remove date and time stamp from log output as the plugin SDK already adds its own
 	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
 
+	// the provider binary also works as a CLI when a subcommand is specified, e.g. `terraform-provider-azapi validate`
+	if len(os.Args) > 1 && cmd.IsCommand(os.Args[1]) {
+		os.Exit(cmd.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
@@ -21,14 +28,14 @@ func main() {
 	if debugMode {
 		err := plugin.Debug(context.Background(), "registry.terraform.io/Azure/azapi",
 			&plugin.ServeOpts{
-				ProviderFunc: provider.AzureProvider,
+				GRPCProviderFunc: provider.AzureProviderServer,
 			})
 		if err != nil {
 			log.Println(err.Error())
 		}
 	} else {
 		plugin.Serve(&plugin.ServeOpts{
-			ProviderFunc: provider.AzureProvider,
+			GRPCProviderFunc: provider.AzureProviderServer,
 		})
 	}
 }
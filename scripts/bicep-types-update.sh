@@ -16,6 +16,10 @@ main() {
     cd $ROOTDIR/internal/azure/generated
     find . -name "*.md" -type f -delete
     find . -name "*.out" -type f -delete
+    echo "compiling type files"
+    cd $ROOTDIR
+    go generate ./internal/azure/
+    echo "done"
 }
 
 main "$@"
\ No newline at end of file
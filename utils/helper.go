@@ -130,6 +130,16 @@ func GetParentId(id string) string {
 	return parentId
 }
 
+// SplitResourceType splits the type in a format like `<resource-type>@<api-version>`.
+// It returns false if the type isn't in the format, and the resource type is the input in that case.
+func SplitResourceType(input string) (string, string, bool) {
+	resourceType, apiVersion, found := strings.Cut(input, "@")
+	if !found || strings.Contains(apiVersion, "@") {
+		return input, "", false
+	}
+	return resourceType, apiVersion, true
+}
+
 func GetParentType(resourceType string) string {
 	parts := strings.Split(resourceType, "/")
 	if len(parts) <= 2 {
@@ -181,6 +191,93 @@ func GetScopeType(id string) types.ScopeType {
 	return types.Unknown
 }
 
+func GetSubscriptionId(id string) string {
+	components := getIdComponents(id)
+	if len(components) >= 2 && strings.EqualFold(components[0], "subscriptions") {
+		return components[1]
+	}
+	return ""
+}
+
+func GetResourceGroupName(id string) string {
+	components := getIdComponents(id)
+	if len(components) >= 4 && strings.EqualFold(components[0], "subscriptions") && strings.EqualFold(components[2], "resourceGroups") {
+		return components[3]
+	}
+	return ""
+}
+
+func GetProviderNamespace(resourceType string) string {
+	switch resourceType {
+	case "Tenant", "Subscription":
+		return ""
+	}
+	if index := strings.Index(resourceType, "/"); index != -1 {
+		return resourceType[0:index]
+	}
+	return resourceType
+}
+
+// GetIdParts returns the key/value pairs in the id, the `providers` segments are skipped,
+// for example, `/subscriptions/sub1/resourceGroups/rg1` returns {"subscriptions": "sub1", "resourceGroups": "rg1"}.
+func GetIdParts(id string) map[string]string {
+	parts := make(map[string]string)
+	components := getIdComponents(id)
+	for current := 0; current <= len(components)-2; current += 2 {
+		key := components[current]
+		value := components[current+1]
+		if strings.EqualFold(key, "providers") {
+			continue
+		}
+		parts[key] = value
+	}
+	return parts
+}
+
+// BuildResourceIdWithScope builds the id of a resource from the scope id, the resource type and the names of the resource and its parents,
+// for example, scope `/subscriptions/sub1`, type `Microsoft.Network/virtualNetworks/subnets` and names ["vnet1", "subnet1"] returns
+// `/subscriptions/sub1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1`.
+func BuildResourceIdWithScope(scopeId string, resourceType string, names []string) (string, error) {
+	parts := strings.Split(resourceType, "/")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("resource type %q is invalid, expect a format like `Microsoft.Foo/bars`", resourceType)
+	}
+	if len(parts)-1 != len(names) {
+		return "", fmt.Errorf("resource type %q requires %d names, but got %d", resourceType, len(parts)-1, len(names))
+	}
+	for _, name := range names {
+		if name == "" || strings.Contains(name, "/") {
+			return "", fmt.Errorf("resource name %q is invalid, it must not be empty or contain `/`", name)
+		}
+	}
+
+	scopeId = strings.TrimSuffix(scopeId, "/")
+	if strings.EqualFold(resourceType, "Microsoft.Resources/resourceGroups") && GetScopeType(scopeId) == types.Subscription {
+		return fmt.Sprintf("%s/resourceGroups/%s", scopeId, names[0]), nil
+	}
+
+	id := fmt.Sprintf("%s/providers/%s", scopeId, parts[0])
+	for index, name := range names {
+		id = fmt.Sprintf("%s/%s/%s", id, parts[index+1], name)
+	}
+	return id, nil
+}
+
+func getIdComponents(id string) []string {
+	idURL, err := url.ParseRequestURI(id)
+	if err != nil {
+		return nil
+	}
+	path := idURL.Path
+
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimSuffix(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
 func ExpandStringSlice(input []interface{}) *[]string {
 	result := make([]string, 0)
 	for _, item := range input {
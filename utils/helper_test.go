@@ -1,6 +1,9 @@
 package utils_test
 
 import (
+	"encoding/json"
+	"os"
+	"reflect"
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
@@ -159,6 +162,48 @@ func Test_GetParentType(t *testing.T) {
 	}
 }
 
+func Test_SplitResourceType(t *testing.T) {
+	cases := []struct {
+		Input        string
+		ResourceType string
+		ApiVersion   string
+		Ok           bool
+	}{
+		{
+			Input:        "Microsoft.EventHub/clusters@2021-01-01",
+			ResourceType: "Microsoft.EventHub/clusters",
+			ApiVersion:   "2021-01-01",
+			Ok:           true,
+		},
+		{
+			Input:        "Microsoft.EventHub/clusters@",
+			ResourceType: "Microsoft.EventHub/clusters",
+			ApiVersion:   "",
+			Ok:           true,
+		},
+		{
+			Input:        "Microsoft.EventHub/clusters",
+			ResourceType: "Microsoft.EventHub/clusters",
+			ApiVersion:   "",
+			Ok:           false,
+		},
+		{
+			Input:        "Microsoft.EventHub/clusters@2021-01-01@2021-01-01",
+			ResourceType: "Microsoft.EventHub/clusters@2021-01-01@2021-01-01",
+			ApiVersion:   "",
+			Ok:           false,
+		},
+	}
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Input)
+		resourceType, apiVersion, ok := utils.SplitResourceType(tc.Input)
+
+		if tc.ResourceType != resourceType || tc.ApiVersion != apiVersion || tc.Ok != ok {
+			t.Fatalf("Expected %s, %s, %v but got %s, %s, %v", tc.ResourceType, tc.ApiVersion, tc.Ok, resourceType, apiVersion, ok)
+		}
+	}
+}
+
 func Test_GetScopeType(t *testing.T) {
 	cases := []struct {
 		Input  string
@@ -218,3 +263,126 @@ func Test_GetScopeType(t *testing.T) {
 		}
 	}
 }
+
+// resourceIdTestCase is the format of the test cases in testdata/resource_ids.json, they're shared with the provider functions' tests.
+type resourceIdTestCase struct {
+	Id                string            `json:"id"`
+	ParentId          string            `json:"parent_id"`
+	ResourceType      string            `json:"resource_type"`
+	Name              string            `json:"name"`
+	ScopeType         string            `json:"scope_type"`
+	SubscriptionId    string            `json:"subscription_id"`
+	ResourceGroupName string            `json:"resource_group_name"`
+	ProviderNamespace string            `json:"provider_namespace"`
+	Parts             map[string]string `json:"parts"`
+}
+
+func Test_ResourceIdTestData(t *testing.T) {
+	data, err := os.ReadFile("testdata/resource_ids.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []resourceIdTestCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Id)
+
+		if output := utils.GetParentId(tc.Id); output != tc.ParentId {
+			t.Fatalf("Expected parent id %s but got %s", tc.ParentId, output)
+		}
+		if output := utils.GetResourceType(tc.Id); output != tc.ResourceType {
+			t.Fatalf("Expected resource type %s but got %s", tc.ResourceType, output)
+		}
+		if output := utils.GetName(tc.Id); output != tc.Name {
+			t.Fatalf("Expected name %s but got %s", tc.Name, output)
+		}
+		if output := utils.GetScopeType(tc.Id).String(); output != tc.ScopeType {
+			t.Fatalf("Expected scope type %s but got %s", tc.ScopeType, output)
+		}
+		if output := utils.GetSubscriptionId(tc.Id); output != tc.SubscriptionId {
+			t.Fatalf("Expected subscription id %s but got %s", tc.SubscriptionId, output)
+		}
+		if output := utils.GetResourceGroupName(tc.Id); output != tc.ResourceGroupName {
+			t.Fatalf("Expected resource group name %s but got %s", tc.ResourceGroupName, output)
+		}
+		if output := utils.GetProviderNamespace(tc.ResourceType); output != tc.ProviderNamespace {
+			t.Fatalf("Expected provider namespace %s but got %s", tc.ProviderNamespace, output)
+		}
+		if output := utils.GetIdParts(tc.Id); !reflect.DeepEqual(output, tc.Parts) {
+			t.Fatalf("Expected parts %v but got %v", tc.Parts, output)
+		}
+	}
+}
+
+func Test_BuildResourceIdWithScope(t *testing.T) {
+	cases := []struct {
+		ScopeId      string
+		ResourceType string
+		Names        []string
+		Output       string
+		Error        bool
+	}{
+		{
+			ScopeId:      "",
+			ResourceType: "Microsoft.Management/managementGroups",
+			Names:        []string{"myMgmtGroup"},
+			Output:       "/providers/Microsoft.Management/managementGroups/myMgmtGroup",
+		},
+		{
+			ScopeId:      "/subscriptions/12345678-1234-9876-4563-123456789012",
+			ResourceType: "Microsoft.Resources/resourceGroups",
+			Names:        []string{"rg1"},
+			Output:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1",
+		},
+		{
+			ScopeId:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1",
+			ResourceType: "Microsoft.Network/virtualNetworks/subnets",
+			Names:        []string{"vnet1", "subnet1"},
+			Output:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1",
+		},
+		{
+			ScopeId:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.Network/networkSecurityGroups/sg1",
+			ResourceType: "Microsoft.Insights/metrics",
+			Names:        []string{"m1"},
+			Output:       "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1/providers/Microsoft.Network/networkSecurityGroups/sg1/providers/Microsoft.Insights/metrics/m1",
+		},
+		{
+			ScopeId:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1",
+			ResourceType: "Microsoft.Network/virtualNetworks/subnets",
+			Names:        []string{"subnet1"},
+			Error:        true,
+		},
+		{
+			ScopeId:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1",
+			ResourceType: "Microsoft.Network",
+			Names:        []string{},
+			Error:        true,
+		},
+		{
+			ScopeId:      "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/rg1",
+			ResourceType: "Microsoft.Network/virtualNetworks",
+			Names:        []string{"vnet1/subnets"},
+			Error:        true,
+		},
+	}
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s %s %v", tc.ScopeId, tc.ResourceType, tc.Names)
+		output, err := utils.BuildResourceIdWithScope(tc.ScopeId, tc.ResourceType, tc.Names)
+		if err != nil {
+			if tc.Error {
+				continue
+			}
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if tc.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if tc.Output != output {
+			t.Fatalf("Expected %s but got %s", tc.Output, output)
+		}
+	}
+}